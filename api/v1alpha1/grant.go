@@ -0,0 +1,39 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	componentoperatorruntimetypes "github.com/sap/component-operator-runtime/pkg/types"
+
+	"github.com/sap/component-operator/internal/reference"
+)
+
+// check that the object with the given kind and name may be referenced by the given component, according to the reference policy
+// carried by the given context; only the metadata of the referenced object is read for this; errors returned by the client
+// (such as not found errors) are passed through unchanged
+func checkReferenceGrant(ctx context.Context, clnt client.Client, gvk schema.GroupVersionKind, name NamespacedName, component *Component) error {
+	if !reference.PolicyFromContext(ctx).NoCrossNamespaceRefs || name.Namespace == component.Namespace {
+		return nil
+	}
+	object := &metav1.PartialObjectMetadata{}
+	object.SetGroupVersionKind(gvk)
+	if err := clnt.Get(ctx, apitypes.NamespacedName(name), object); err != nil {
+		return err
+	}
+	if !reference.IsGranted(object, component.Namespace) {
+		return componentoperatorruntimetypes.NewRetriableError(fmt.Errorf("cross-namespace reference to %s %s not allowed (not granted by the referenced object)", gvk.Kind, name), new(10*time.Second))
+	}
+	return nil
+}
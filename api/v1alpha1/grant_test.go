@@ -0,0 +1,125 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sap/component-operator/internal/reference"
+	"github.com/sap/component-operator/internal/testutil"
+	"github.com/sap/component-operator/pkg/meta"
+)
+
+func newTestBlueprint(namespace string, name string, grant string) *Blueprint {
+	blueprint := &Blueprint{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Generation: 1},
+		Status: BlueprintStatus{
+			Conditions: []metav1.Condition{{Type: BlueprintConditionTypeValid, Status: metav1.ConditionTrue, ObservedGeneration: 1}},
+		},
+	}
+	if grant != "" {
+		blueprint.Annotations = map[string]string{meta.AnnotationKeyReferenceGrant: grant}
+	}
+	return blueprint
+}
+
+func TestSourceReferenceLoadChecksGrant(t *testing.T) {
+	tests := []struct {
+		name                 string
+		noCrossNamespaceRefs bool
+		blueprint            *Blueprint
+		expectedErr          string
+	}{
+		{name: "same namespace", noCrossNamespaceRefs: true, blueprint: newTestBlueprint("test", "blueprint", "")},
+		{name: "other namespace without check", blueprint: newTestBlueprint("other", "blueprint", "")},
+		{name: "other namespace granted", noCrossNamespaceRefs: true, blueprint: newTestBlueprint("other", "blueprint", "foo, test")},
+		{name: "other namespace granted to all", noCrossNamespaceRefs: true, blueprint: newTestBlueprint("other", "blueprint", "*")},
+		{name: "other namespace not granted", noCrossNamespaceRefs: true, blueprint: newTestBlueprint("other", "blueprint", "foo"), expectedErr: "cross-namespace reference to Blueprint other/blueprint not allowed"},
+		{name: "other namespace without grant", noCrossNamespaceRefs: true, blueprint: newTestBlueprint("other", "blueprint", ""), expectedErr: "cross-namespace reference to Blueprint other/blueprint not allowed"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := reference.NewContext(context.Background(), reference.Policy{NoCrossNamespaceRefs: test.noCrossNamespaceRefs})
+			clnt := newTestRecordingClient(t, test.blueprint)
+			component := &Component{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "component"},
+				Spec: ComponentSpec{
+					SourceRef: SourceReference{Blueprint: &BlueprintReference{NamespacedName: NamespacedName{Namespace: test.blueprint.Namespace, Name: test.blueprint.Name}}},
+				},
+			}
+			ref := &component.Spec.SourceRef
+			err := ref.Load(ctx, clnt, component)
+			if testutil.CheckError(t, err, test.expectedErr) {
+				if len(clnt.reads) > 0 || len(clnt.writes) > 0 {
+					t.Errorf("expected denied reference to cause no reads and writes, got reads %v, writes %v", clnt.reads, clnt.writes)
+				}
+				if component.Status.SourceRef != nil {
+					t.Errorf("expected denied reference not to be recorded in status, got %v", component.Status.SourceRef)
+				}
+				return
+			}
+			if len(clnt.reads) != 1 || len(clnt.writes) != 1 {
+				t.Errorf("expected blueprint to be read and blueprint version to be written, got reads %v, writes %v", clnt.reads, clnt.writes)
+			}
+			if component.Status.SourceRef == nil || !strings.HasPrefix(component.Status.SourceRef.Artifact.Url, "blueprint://"+test.blueprint.Namespace+"/") {
+				t.Errorf("unexpected source reference status: %v", component.Status.SourceRef)
+			}
+		})
+	}
+}
+
+func TestDependencyLoadChecksGrant(t *testing.T) {
+	ctx := reference.NewContext(context.Background(), reference.Policy{NoCrossNamespaceRefs: true})
+
+	newDependency := func(namespace string) *Component {
+		return &Component{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "dependency"},
+			Status:     ComponentStatus{Exports: map[string]apiextensionsv1.JSON{"x": {Raw: []byte(`"y"`)}}},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		dependency  *Component
+		grant       string
+		imports     []Import
+		expectedErr string
+	}{
+		{name: "granted with imports", dependency: newDependency("other"), grant: "test", imports: []Import{{Export: "x"}}},
+		{name: "not granted with imports", dependency: newDependency("other"), imports: []Import{{Export: "x"}}, expectedErr: "cross-namespace reference to Component other/dependency not allowed"},
+		{name: "not granted without imports", dependency: newDependency("other"), expectedErr: "cross-namespace reference to Component other/dependency not allowed"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.grant != "" {
+				test.dependency.Annotations = map[string]string{meta.AnnotationKeyReferenceGrant: test.grant}
+			}
+			clnt := newTestRecordingClient(t, test.dependency)
+			component := &Component{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "component"}}
+			dependency := &Dependency{
+				NamespacedName: NamespacedName{Namespace: test.dependency.Namespace, Name: test.dependency.Name},
+				Imports:        test.imports,
+			}
+			err := dependency.Load(ctx, clnt, component)
+			if testutil.CheckError(t, err, test.expectedErr) {
+				if len(clnt.reads) > 0 || len(clnt.writes) > 0 {
+					t.Errorf("expected denied dependency to cause no reads and writes, got reads %v, writes %v", clnt.reads, clnt.writes)
+				}
+				return
+			}
+			if value := string(dependency.ExportedValue("x")); value != `"y"` {
+				t.Errorf("expected imported value \"y\", got %s", value)
+			}
+		})
+	}
+}
@@ -8,13 +8,16 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	apitypes "k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -25,12 +28,19 @@ import (
 	"github.com/sap/component-operator-runtime/pkg/manifests"
 	componentoperatorruntimetypes "github.com/sap/component-operator-runtime/pkg/types"
 
+	"github.com/sap/component-operator/internal/datasource"
+	gitrepositoryutil "github.com/sap/component-operator/internal/gitrepository/util"
 	httprepositoryutil "github.com/sap/component-operator/internal/httprepository/util"
 	"github.com/sap/component-operator/internal/object"
+	ocirepositoryutil "github.com/sap/component-operator/internal/ocirepository/util"
 	"github.com/sap/component-operator/pkg/meta"
 )
 
 // ComponentSpec defines the desired state of Component.
+// +kubebuilder:validation:XValidation:rule="!has(self.helm) || !has(self.generator) || self.generator == 'auto' || self.generator == 'helm'",message="Field 'helm' is only allowed if generator is 'auto' or 'helm'"
+// +kubebuilder:validation:XValidation:rule="!has(self.plain) || (has(self.generator) && self.generator == 'plain')",message="Field 'plain' is only allowed if generator is 'plain'"
+// +kubebuilder:validation:XValidation:rule="!has(self.jsonnet) || (has(self.generator) && self.generator == 'jsonnet')",message="Field 'jsonnet' is only allowed if generator is 'jsonnet'"
+// +kubebuilder:validation:XValidation:rule="!has(self.cue) || (has(self.generator) && self.generator == 'cue')",message="Field 'cue' is only allowed if generator is 'cue'"
 type ComponentSpec struct {
 	component.PlacementSpec     `json:",inline"`
 	component.ClientSpec        `json:",inline"`
@@ -43,19 +53,31 @@ type ComponentSpec struct {
 	component.TypeSpec          `json:",inline"`
 	component.ReapplySpec       `json:",inline"`
 	// +required
-	SourceRef    SourceReference                `json:"sourceRef"`
-	Digest       string                         `json:"digest,omitempty"`
-	Revision     string                         `json:"revision,omitempty"`
-	Sticky       bool                           `json:"sticky,omitempty"`
-	Path         string                         `json:"path,omitempty"`
-	Values       *apiextensionsv1.JSON          `json:"values,omitempty"`
-	ValuesFrom   []component.SecretKeyReference `json:"valuesFrom,omitempty" fallbackKeys:"values,values.yaml,values.yml" notFoundPolicy:"ignoreOnDeletion"`
-	Decryption   *Decryption                    `json:"decryption,omitempty"`
-	PostBuild    *PostBuild                     `json:"postBuild,omitempty"`
-	Dependencies []Dependency                   `json:"dependencies,omitempty"`
+	SourceRef     SourceReference          `json:"sourceRef"`
+	Digest        string                   `json:"digest,omitempty"`
+	Revision      string                   `json:"revision,omitempty"`
+	Sticky        bool                     `json:"sticky,omitempty"`
+	Path          string                   `json:"path,omitempty"`
+	Generator     GeneratorType            `json:"generator,omitempty"`
+	Helm          *HelmGeneratorOptions    `json:"helm,omitempty"`
+	Plain         *PlainGeneratorOptions   `json:"plain,omitempty"`
+	Jsonnet       *JsonnetGeneratorOptions `json:"jsonnet,omitempty"`
+	Cue           *CueGeneratorOptions     `json:"cue,omitempty"`
+	Values        *apiextensionsv1.JSON    `json:"values,omitempty"`
+	ValuesFrom    []ValuesReference        `json:"valuesFrom,omitempty"`
+	MergeStrategy *ValuesMergeStrategy     `json:"mergeStrategy,omitempty"`
+	Decryption    *Decryption              `json:"decryption,omitempty"`
+	PostBuild     *PostBuild               `json:"postBuild,omitempty"`
+	Dependencies  []Dependency             `json:"dependencies,omitempty"`
+	// Values exported by this component, which can be imported by depending components.
+	// +listType=map
+	// +listMapKey=name
+	Exports []Export `json:"exports,omitempty"`
+	// Settings controlling how depending components are handled when this component is deleted.
+	Teardown *Teardown `json:"teardown,omitempty"`
 }
 
-// +kubebuilder:validation:XValidation:rule="has(self.blueprint) && !has(self.httpRepository) && !has(self.fluxGitRepository) && !has(self.fluxOciRepository) && !has(self.fluxBucket) && !has(self.fluxHelmChart) || !has(self.blueprint) && has(self.httpRepository) && !has(self.fluxGitRepository) && !has(self.fluxOciRepository) && !has(self.fluxBucket) && !has(self.fluxHelmChart) || !has(self.blueprint) && !has(self.httpRepository) && has(self.fluxGitRepository) && !has(self.fluxOciRepository) && !has(self.fluxBucket) && !has(self.fluxHelmChart) || !has(self.blueprint) && !has(self.httpRepository) && !has(self.fluxGitRepository) && has(self.fluxOciRepository) && !has(self.fluxBucket) && !has(self.fluxHelmChart) || !has(self.blueprint) && !has(self.httpRepository) && !has(self.fluxGitRepository) && !has(self.fluxOciRepository) && has(self.fluxBucket) && !has(self.fluxHelmChart) || !has(self.blueprint) && !has(self.httpRepository) && !has(self.fluxGitRepository) && !has(self.fluxOciRepository) && !has(self.fluxBucket) && has(self.fluxHelmChart)",message="Exactly one of 'blueprint' or 'httpRepository' or 'fluxGitRepository' or 'fluxOciRepository' or 'fluxBucket' or 'fluxHelmChart' must be provided"
+// +kubebuilder:validation:XValidation:rule="[has(self.blueprint), has(self.httpRepository), has(self.ociRepository), has(self.gitRepository), has(self.configMap), has(self.secret), has(self.fluxGitRepository), has(self.fluxOciRepository), has(self.fluxBucket), has(self.fluxHelmChart)].filter(x, x).size() == 1",message="Exactly one of 'blueprint' or 'httpRepository' or 'ociRepository' or 'gitRepository' or 'configMap' or 'secret' or 'fluxGitRepository' or 'fluxOciRepository' or 'fluxBucket' or 'fluxHelmChart' must be provided"
 
 // SourceReference models the source of the templates used to render the dependent resources.
 // Exactly one of the options must be provided. Before accessing the Artifact() method,
@@ -63,6 +85,10 @@ type ComponentSpec struct {
 type SourceReference struct {
 	Blueprint         *BlueprintReference         `json:"blueprint,omitempty"`
 	HttpRepository    *HttpRepository             `json:"httpRepository,omitempty"`
+	OciRepository     *OciRepository              `json:"ociRepository,omitempty"`
+	GitRepository     *GitRepository              `json:"gitRepository,omitempty"`
+	ConfigMap         *ConfigMapSourceReference   `json:"configMap,omitempty"`
+	Secret            *SecretSourceReference      `json:"secret,omitempty"`
 	FluxGitRepository *FluxGitRepositoryReference `json:"fluxGitRepository,omitempty"`
 	FluxOciRepository *FluxOciRepositoryReference `json:"fluxOciRepository,omitempty"`
 	FluxBucket        *FluxBucketReference        `json:"fluxBucket,omitempty"`
@@ -99,14 +125,29 @@ func (r *SourceReference) Load(ctx context.Context, clnt client.Client, componen
 
 		switch {
 		case sourceRef.Blueprint != nil:
+			blueprintName := sourceRef.Blueprint.WithDefaultNamespace(component.Namespace)
+			if err := checkReferenceGrant(ctx, clnt, GroupVersion.WithKind(KindBlueprint), blueprintName, component); err != nil {
+				if apierrors.IsNotFound(err) {
+					return componentoperatorruntimetypes.NewRetriableError(err, new(10*time.Second))
+				}
+				return err
+			}
 			blueprint := &Blueprint{}
-			if err := clnt.Get(ctx, apitypes.NamespacedName(sourceRef.Blueprint.WithDefaultNamespace(component.Namespace)), blueprint); err != nil {
+			if err := clnt.Get(ctx, apitypes.NamespacedName(blueprintName), blueprint); err != nil {
 				if apierrors.IsNotFound(err) {
 					return componentoperatorruntimetypes.NewRetriableError(err, new(10*time.Second))
 				}
 				return err
 			}
 
+			// note: blueprints are validated by the blueprint controller (and by the admission webhook, if enabled); blueprints which
+			// are not (yet) validated or invalid are refused here, such that invalid blueprints are never used, even without the webhook
+			if condition := apimeta.FindStatusCondition(blueprint.Status.Conditions, BlueprintConditionTypeValid); condition == nil || condition.ObservedGeneration != blueprint.Generation {
+				return componentoperatorruntimetypes.NewRetriableError(fmt.Errorf("blueprint %s/%s not yet validated", blueprint.Namespace, blueprint.Name), new(10*time.Second))
+			} else if condition.Status != metav1.ConditionTrue {
+				return componentoperatorruntimetypes.NewRetriableError(fmt.Errorf("blueprint %s/%s is invalid: %s", blueprint.Namespace, blueprint.Name, condition.Message), new(10*time.Second))
+			}
+
 			blueprintDigest := blueprint.GetDigest()
 			blueprintRevision := blueprint.GetRevision()
 			blueprintVersion := &BlueprintVersion{
@@ -134,7 +175,18 @@ func (r *SourceReference) Load(ctx context.Context, clnt client.Client, componen
 			sourceRefArtifact.Revision = blueprintRevision
 			digestData = []any{sourceRefArtifact.Url, sourceRefArtifact.Digest, sourceRefArtifact.Revision}
 		case sourceRef.HttpRepository != nil:
-			url, digest, revision, err := httprepositoryutil.GetArtifact(sourceRef.HttpRepository.Url, sourceRef.HttpRepository.DigestHeader, sourceRef.HttpRepository.RevisionHeader)
+			var credentials *httprepositoryutil.Credentials
+			if sourceRef.HttpRepository.SecretRef != nil {
+				var err error
+				credentials, err = httprepositoryutil.GetCredentials(ctx, clnt, component.Namespace, sourceRef.HttpRepository.SecretRef.Name)
+				if err != nil {
+					if apierrors.IsNotFound(err) {
+						return componentoperatorruntimetypes.NewRetriableError(err, new(10*time.Second))
+					}
+					return err
+				}
+			}
+			url, digest, revision, err := httprepositoryutil.GetArtifact(sourceRef.HttpRepository.Url, sourceRef.HttpRepository.DigestHeader, sourceRef.HttpRepository.RevisionHeader, credentials)
 			if err != nil {
 				return err
 			}
@@ -142,28 +194,142 @@ func (r *SourceReference) Load(ctx context.Context, clnt client.Client, componen
 			sourceRefArtifact.Url = url
 			sourceRefArtifact.Digest = digest
 			sourceRefArtifact.Revision = revision
+			sourceRefArtifact.Format = sourceRef.HttpRepository.Format
 			digestData = []any{sourceRefArtifact.Url, sourceRefArtifact.Digest, sourceRefArtifact.Revision}
+			if sourceRefArtifact.Format != "" {
+				digestData = append(digestData, sourceRefArtifact.Format)
+			}
+		case sourceRef.OciRepository != nil:
+			var credentials *ocirepositoryutil.Credentials
+			if sourceRef.OciRepository.SecretRef != nil {
+				var err error
+				credentials, err = ocirepositoryutil.GetCredentials(ctx, clnt, component.Namespace, sourceRef.OciRepository.SecretRef.Name)
+				if err != nil {
+					if apierrors.IsNotFound(err) {
+						return componentoperatorruntimetypes.NewRetriableError(err, new(10*time.Second))
+					}
+					return err
+				}
+			}
+			var refTag, refDigest string
+			if sourceRef.OciRepository.Ref != nil {
+				refTag = sourceRef.OciRepository.Ref.Tag
+				refDigest = sourceRef.OciRepository.Ref.Digest
+			}
+			url, digest, revision, err := ocirepositoryutil.GetArtifact(sourceRef.OciRepository.Url, refTag, refDigest, sourceRef.OciRepository.LayerMediaType, sourceRef.OciRepository.Insecure, credentials)
+			if err != nil {
+				return err
+			}
+
+			sourceRefArtifact.Url = url
+			sourceRefArtifact.Digest = digest
+			sourceRefArtifact.Revision = revision
+			digestData = []any{sourceRefArtifact.Url, sourceRefArtifact.Digest, sourceRefArtifact.Revision}
+		case sourceRef.GitRepository != nil:
+			var credentials *gitrepositoryutil.Credentials
+			if sourceRef.GitRepository.SecretRef != nil {
+				var err error
+				credentials, err = gitrepositoryutil.GetCredentials(ctx, clnt, component.Namespace, sourceRef.GitRepository.SecretRef.Name)
+				if err != nil {
+					if apierrors.IsNotFound(err) {
+						return componentoperatorruntimetypes.NewRetriableError(err, new(10*time.Second))
+					}
+					return err
+				}
+			}
+			var ref gitrepositoryutil.Ref
+			if sourceRef.GitRepository.Ref != nil {
+				ref = gitrepositoryutil.Ref(*sourceRef.GitRepository.Ref)
+			}
+			url, digest, revision, err := gitrepositoryutil.GetArtifact(sourceRef.GitRepository.Url, ref, credentials)
+			if err != nil {
+				return err
+			}
+
+			sourceRefArtifact.Url = url
+			sourceRefArtifact.Digest = digest
+			sourceRefArtifact.Revision = revision
+			digestData = []any{sourceRefArtifact.Url, sourceRefArtifact.Digest, sourceRefArtifact.Revision}
+		case sourceRef.ConfigMap != nil:
+			configMapName := sourceRef.ConfigMap.WithDefaultNamespace(component.Namespace)
+			if err := checkReferenceGrant(ctx, clnt, corev1.SchemeGroupVersion.WithKind("ConfigMap"), configMapName, component); err != nil {
+				if apierrors.IsNotFound(err) {
+					return componentoperatorruntimetypes.NewRetriableError(err, new(10*time.Second))
+				}
+				return err
+			}
+			configMap := &corev1.ConfigMap{}
+			if err := clnt.Get(ctx, apitypes.NamespacedName(configMapName), configMap); err != nil {
+				if apierrors.IsNotFound(err) {
+					return componentoperatorruntimetypes.NewRetriableError(err, new(10*time.Second))
+				}
+				return err
+			}
+			files, err := sourceRef.ConfigMap.GetFiles(configMap)
+			if err != nil {
+				return fmt.Errorf("invalid config map %s/%s: %w", configMap.Namespace, configMap.Name, err)
+			}
+
+			sourceRefArtifact.Digest = datasource.CalculateDigest(files)
+			sourceRefArtifact.Url = datasource.ArtifactUrl(datasource.KindConfigMap, configMap.Namespace, configMap.Name, sourceRefArtifact.Digest, itemsToMapping(sourceRef.ConfigMap.Items))
+			sourceRefArtifact.Revision = fmt.Sprintf("resourceVersion:%s", configMap.ResourceVersion)
+			digestData = []any{sourceRefArtifact.Url, sourceRefArtifact.Digest}
+		case sourceRef.Secret != nil:
+			secretName := sourceRef.Secret.WithDefaultNamespace(component.Namespace)
+			if err := checkReferenceGrant(ctx, clnt, corev1.SchemeGroupVersion.WithKind("Secret"), secretName, component); err != nil {
+				if apierrors.IsNotFound(err) {
+					return componentoperatorruntimetypes.NewRetriableError(err, new(10*time.Second))
+				}
+				return err
+			}
+			secret := &corev1.Secret{}
+			if err := clnt.Get(ctx, apitypes.NamespacedName(secretName), secret); err != nil {
+				if apierrors.IsNotFound(err) {
+					return componentoperatorruntimetypes.NewRetriableError(err, new(10*time.Second))
+				}
+				return err
+			}
+			files, err := sourceRef.Secret.GetFiles(secret)
+			if err != nil {
+				return fmt.Errorf("invalid secret %s/%s: %w", secret.Namespace, secret.Name, err)
+			}
+
+			sourceRefArtifact.Digest = datasource.CalculateDigest(files)
+			sourceRefArtifact.Url = datasource.ArtifactUrl(datasource.KindSecret, secret.Namespace, secret.Name, sourceRefArtifact.Digest, itemsToMapping(sourceRef.Secret.Items))
+			sourceRefArtifact.Revision = fmt.Sprintf("resourceVersion:%s", secret.ResourceVersion)
+			digestData = []any{sourceRefArtifact.Url, sourceRefArtifact.Digest}
 		case sourceRef.FluxGitRepository != nil, sourceRef.FluxOciRepository != nil, sourceRef.FluxBucket != nil, sourceRef.FluxHelmChart != nil:
 			var sourceName NamespacedName
+			var sourceKind string
 			var source meta.FluxSource
 
 			switch {
 			case sourceRef.FluxGitRepository != nil:
 				sourceName = sourceRef.FluxGitRepository.WithDefaultNamespace(component.Namespace)
+				sourceKind = fluxsourcev1.GitRepositoryKind
 				source = &fluxsourcev1.GitRepository{}
 			case sourceRef.FluxOciRepository != nil:
 				sourceName = sourceRef.FluxOciRepository.WithDefaultNamespace(component.Namespace)
+				sourceKind = fluxsourcev1.OCIRepositoryKind
 				source = &fluxsourcev1.OCIRepository{}
 			case sourceRef.FluxBucket != nil:
 				sourceName = sourceRef.FluxBucket.WithDefaultNamespace(component.Namespace)
+				sourceKind = fluxsourcev1.BucketKind
 				source = &fluxsourcev1.Bucket{}
 			case sourceRef.FluxHelmChart != nil:
 				sourceName = sourceRef.FluxHelmChart.WithDefaultNamespace(component.Namespace)
+				sourceKind = fluxsourcev1.HelmChartKind
 				source = &fluxsourcev1.HelmChart{}
 			default:
 				panic("this cannot happen")
 			}
 
+			if err := checkReferenceGrant(ctx, clnt, fluxsourcev1.GroupVersion.WithKind(sourceKind), sourceName, component); err != nil {
+				if apimeta.IsNoMatchError(err) || apierrors.IsNotFound(err) {
+					return componentoperatorruntimetypes.NewRetriableError(err, new(10*time.Second))
+				}
+				return err
+			}
 			if err := clnt.Get(ctx, apitypes.NamespacedName(sourceName), source); err != nil {
 				if apimeta.IsNoMatchError(err) || apierrors.IsNotFound(err) {
 					return componentoperatorruntimetypes.NewRetriableError(err, new(10*time.Second))
@@ -194,7 +360,7 @@ func (r *SourceReference) Load(ctx context.Context, clnt client.Client, componen
 			sourceRefArtifact.Revision = artifact.Revision
 			digestData = []any{source.GetUID(), source.GetGeneration(), source.GetAnnotations(), sourceRefArtifact.Url, sourceRefArtifact.Digest, sourceRefArtifact.Revision}
 		default:
-			return fmt.Errorf("unable to get source; one of httpRepository, fluxGitRepository, fluxOciRepository, fluxBucket, fluxHelmChart must be defined")
+			return fmt.Errorf("unable to get source; one of blueprint, httpRepository, ociRepository, gitRepository, configMap, secret, fluxGitRepository, fluxOciRepository, fluxBucket, fluxHelmChart must be defined")
 		}
 
 		r.artifact = sourceRefArtifact
@@ -231,7 +397,11 @@ func (r *SourceReference) Artifact() Artifact {
 // Check if source reference equals other given source reference.
 func (r *SourceReference) Equals(s *SourceReference) bool {
 	return equal(r.Blueprint, s.Blueprint) &&
-		equal(r.HttpRepository, s.HttpRepository) &&
+		equalFunc(r.HttpRepository, s.HttpRepository, (*HttpRepository).Equals) &&
+		equalFunc(r.OciRepository, s.OciRepository, (*OciRepository).Equals) &&
+		equalFunc(r.GitRepository, s.GitRepository, (*GitRepository).Equals) &&
+		equalFunc(r.ConfigMap, s.ConfigMap, (*ConfigMapSourceReference).Equals) &&
+		equalFunc(r.Secret, s.Secret, (*SecretSourceReference).Equals) &&
 		equal(r.FluxGitRepository, s.FluxGitRepository) &&
 		equal(r.FluxOciRepository, s.FluxOciRepository) &&
 		equal(r.FluxBucket, s.FluxBucket) &&
@@ -245,16 +415,161 @@ type BlueprintReference struct {
 
 // Reference to a generic http repository.
 type HttpRepository struct {
-	// URL of the source. Authentication is currently not supported. The operator will make HEAD requests to retrieve the digest/revision
+	// URL of the source. The operator will make HEAD requests to retrieve the digest/revision
 	// and a potentially redirected actual location of the source artifact. Redirects will be followed as long as the response does not
 	// contain the specified digest header.
 	Url string `json:"url,omitempty"`
 	// Name of the header containing the digest of the source artifact. The returned header value can be any format, but must uniquely identify the
-	// content of the source artifact. Defaults to the ETag header.
+	// content of the source artifact. Defaults to the ETag header. If the header value has the format <algorithm>:<hex>, where algorithm
+	// is one of sha256, sha384, sha512, blake3, the downloaded source artifact will be verified against it.
+	// Otherwise (e.g. for ETags), the downloaded content is not verified, and therefore not stored in the operator's artifact cache.
 	DigestHeader string `json:"digestHeader,omitempty"`
 	// Name of the header containing the revision of the source artifact. The returned header value can be any format.
 	// Defaults to the header specified in DigestHeader.
 	RevisionHeader string `json:"revisionHeader,omitempty"`
+	// Reference to a secret containing credentials for the http repository. The secret may contain the keys
+	// 'username' and 'password' (basic authentication) or 'bearerToken' (bearer token authentication),
+	// 'tls.crt' and 'tls.key' (client certificate authentication), and 'ca.crt' (custom CA bundle).
+	// The credentials are used both for retrieving the digest/revision and for downloading the source artifact.
+	SecretRef *component.SecretReference `json:"secretRef,omitempty" notFoundPolicy:"ignoreOnDeletion"`
+	// Archive format of the source artifact. If omitted, the format is detected from the leading bytes of the
+	// downloaded artifact, or from its Content-Type header.
+	// +kubebuilder:validation:Enum=tar;tar+gzip;tar+zstd;zip
+	Format string `json:"format,omitempty"`
+}
+
+// Check if http repository equals other given http repository.
+func (r *HttpRepository) Equals(s *HttpRepository) bool {
+	return r.Url == s.Url &&
+		r.DigestHeader == s.DigestHeader &&
+		r.RevisionHeader == s.RevisionHeader &&
+		r.Format == s.Format &&
+		equalFunc(r.SecretRef, s.SecretRef, func(x *component.SecretReference, y *component.SecretReference) bool { return x.Name == y.Name })
+}
+
+// Reference to an OCI repository. The operator resolves the specified tag or digest against the registry,
+// and uses the digest of the resolved manifest as digest of the source artifact.
+type OciRepository struct {
+	// URL of the OCI repository, in the format oci://<registry host>/<repository>.
+	// +required
+	// +kubebuilder:validation:Pattern=`^oci://[^/]+/.+$`
+	Url string `json:"url"`
+	// Tag or digest of the OCI artifact. If omitted, the tag 'latest' is used.
+	Ref *OciRepositoryRef `json:"ref,omitempty"`
+	// Media type of the layer containing the source artifact; the layer must be a (plain, gzip- or zstd-compressed) tarball,
+	// or a zip archive.
+	// If omitted, the first layer with media type 'application/vnd.cncf.flux.content.v1.tar+gzip' is used,
+	// or the first layer at all, if there is no such layer.
+	LayerMediaType string `json:"layerMediaType,omitempty"`
+	// Allow connecting to the registry through plain http (instead of https).
+	Insecure bool `json:"insecure,omitempty"`
+	// Reference to a secret containing the registry credentials (key '.dockerconfigjson', as in secrets of type kubernetes.io/dockerconfigjson),
+	// and/or a custom CA bundle (key 'ca.crt').
+	SecretRef *component.SecretReference `json:"secretRef,omitempty" notFoundPolicy:"ignoreOnDeletion"`
+}
+
+// Check if OCI repository equals other given OCI repository.
+func (r *OciRepository) Equals(s *OciRepository) bool {
+	return r.Url == s.Url &&
+		equal(r.Ref, s.Ref) &&
+		r.LayerMediaType == s.LayerMediaType &&
+		r.Insecure == s.Insecure &&
+		equalFunc(r.SecretRef, s.SecretRef, func(x *component.SecretReference, y *component.SecretReference) bool { return x.Name == y.Name })
+}
+
+// Reference to an OCI artifact; if both tag and digest are specified, digest takes precedence.
+type OciRepositoryRef struct {
+	// Tag of the OCI artifact.
+	Tag string `json:"tag,omitempty"`
+	// Digest of the OCI artifact, in the format <algorithm>:<hex>.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]+:[a-f0-9]+$`
+	Digest string `json:"digest,omitempty"`
+}
+
+// Reference to a git repository. The operator resolves the specified reference against the remote repository,
+// and uses the commit as digest of the source artifact; the revision has the format <branch or tag>@sha1:<commit>, like flux.
+type GitRepository struct {
+	// URL of the git repository; supported schemes are https, http and ssh.
+	// +required
+	// +kubebuilder:validation:Pattern=`^(https?|ssh)://.+$`
+	Url string `json:"url"`
+	// Reference (branch, tag, semver range or commit) to be checked out. If omitted, the default branch of the repository is used.
+	Ref *GitRepositoryRef `json:"ref,omitempty"`
+	// Reference to a secret containing credentials for the git repository. For https repositories, the secret may contain the keys
+	// 'username' and 'password' (basic authentication) or 'bearerToken' (bearer token authentication), and 'ca.crt' (custom CA bundle).
+	// For ssh repositories, the secret must contain the key 'known_hosts', and may contain the key 'identity' (private key).
+	SecretRef *component.SecretReference `json:"secretRef,omitempty" notFoundPolicy:"ignoreOnDeletion"`
+}
+
+// Check if git repository equals other given git repository.
+func (r *GitRepository) Equals(s *GitRepository) bool {
+	return r.Url == s.Url &&
+		equal(r.Ref, s.Ref) &&
+		equalFunc(r.SecretRef, s.SecretRef, func(x *component.SecretReference, y *component.SecretReference) bool { return x.Name == y.Name })
+}
+
+// Reference to a git commit; precedence is commit, semver, tag, branch.
+type GitRepositoryRef struct {
+	// Branch to be checked out.
+	Branch string `json:"branch,omitempty"`
+	// Tag to be checked out.
+	Tag string `json:"tag,omitempty"`
+	// SemVer range; the latest tag matching the range will be checked out.
+	SemVer string `json:"semver,omitempty"`
+	// Commit (full sha1 hash) to be checked out. If branch is specified as well, it is only used to build the revision.
+	// +kubebuilder:validation:Pattern=`^[a-f0-9]{40}$`
+	Commit string `json:"commit,omitempty"`
+}
+
+// Reference to a ConfigMap containing the source files. The digest of the source artifact is calculated from the content of the ConfigMap.
+type ConfigMapSourceReference struct {
+	NamespacedName `json:",inline"`
+	// Mapping of keys to file paths. If omitted, every key of the ConfigMap (data and binaryData) becomes a file in the root directory
+	// of the source artifact; otherwise only the listed keys are considered.
+	// +listType=map
+	// +listMapKey=key
+	Items []KeyToPath `json:"items,omitempty"`
+}
+
+// Check if config map source reference equals other given config map source reference.
+func (r *ConfigMapSourceReference) Equals(s *ConfigMapSourceReference) bool {
+	return r.NamespacedName == s.NamespacedName && slices.Equal(r.Items, s.Items)
+}
+
+// Get the files (paths to content) provided by the given ConfigMap.
+func (r *ConfigMapSourceReference) GetFiles(configMap *corev1.ConfigMap) (map[string][]byte, error) {
+	return datasource.GetFiles(datasource.ConfigMapData(configMap), itemsToMapping(r.Items))
+}
+
+// Reference to a Secret containing the source files. The digest of the source artifact is calculated from the content of the Secret.
+type SecretSourceReference struct {
+	NamespacedName `json:",inline"`
+	// Mapping of keys to file paths. If omitted, every key of the Secret becomes a file in the root directory
+	// of the source artifact; otherwise only the listed keys are considered.
+	// +listType=map
+	// +listMapKey=key
+	Items []KeyToPath `json:"items,omitempty"`
+}
+
+// Check if secret source reference equals other given secret source reference.
+func (r *SecretSourceReference) Equals(s *SecretSourceReference) bool {
+	return r.NamespacedName == s.NamespacedName && slices.Equal(r.Items, s.Items)
+}
+
+// Get the files (paths to content) provided by the given Secret.
+func (r *SecretSourceReference) GetFiles(secret *corev1.Secret) (map[string][]byte, error) {
+	return datasource.GetFiles(secret.Data, itemsToMapping(r.Items))
+}
+
+// Mapping of a ConfigMap or Secret key to a file path.
+type KeyToPath struct {
+	// The key to be mapped.
+	// +required
+	Key string `json:"key"`
+	// Relative path of the file the key is mapped to; may contain directories, but must not contain '..'.
+	// +required
+	// +kubebuilder:validation:Pattern=`^[^/]`
+	Path string `json:"path"`
 }
 
 // Reference to a flux GitRepository.
@@ -277,6 +592,175 @@ type FluxHelmChartReference struct {
 	NamespacedName `json:",inline"`
 }
 
+// ValuesReference references a key of a Secret or ConfigMap (in the namespace of the component) containing values.
+// The content is parsed as YAML; without targetPath, it must be a mapping, which is deep-merged into the values at the root.
+// Otherwise, the content is placed at targetPath; mappings and sequences are inserted as structured values, any other content
+// (such as a plain version string) is inserted as string.
+type ValuesReference struct {
+	// Kind of the referenced object. Defaults to Secret.
+	// +kubebuilder:validation:Enum=Secret;ConfigMap
+	Kind ValuesReferenceKind `json:"kind,omitempty"`
+	// Name of the referenced object.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Key containing the values. If omitted, the keys values, values.yaml and values.yml are tried (in this order).
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key,omitempty"`
+	// Dotted path (such as image.tag) at which the content is placed; dots contained in keys can be escaped by a backslash.
+	// +kubebuilder:validation:MinLength=1
+	TargetPath string `json:"targetPath,omitempty"`
+	// If true, a missing object or key is ignored; otherwise, the reconciliation of the component is retried until it exists.
+	Optional bool `json:"optional,omitempty"`
+	// Strategy used to merge the content into the values; overrides the merge strategy of the component.
+	MergeStrategy *ValuesMergeStrategy `json:"mergeStrategy,omitempty"`
+	value         []byte               `json:"-"`
+	digest        string               `json:"-"`
+	loaded        bool                 `json:"-"`
+}
+
+// ValuesReferenceKind denotes the kind of object referenced by a ValuesReference.
+type ValuesReferenceKind string
+
+const (
+	ValuesReferenceKindSecret    ValuesReferenceKind = "Secret"
+	ValuesReferenceKindConfigMap ValuesReferenceKind = "ConfigMap"
+)
+
+var _ component.Reference[*Component] = &ValuesReference{}
+
+// Implement the component.Reference interface.
+func (r *ValuesReference) Load(ctx context.Context, clnt client.Client, component *Component) error {
+	if r.loaded {
+		// note: this panic indicates a programmatic error on the consumer side
+		panic("reference already initialized")
+	}
+
+	if !component.DeletionTimestamp.IsZero() {
+		r.loaded = true
+		return nil
+	}
+
+	var object client.Object
+	var data map[string][]byte
+	switch r.Kind {
+	case ValuesReferenceKindSecret, "":
+		secret := &corev1.Secret{}
+		object = secret
+		if err := clnt.Get(ctx, apitypes.NamespacedName{Namespace: component.Namespace, Name: r.Name}, secret); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			object = nil
+		}
+		data = secret.Data
+	case ValuesReferenceKindConfigMap:
+		configMap := &corev1.ConfigMap{}
+		object = configMap
+		if err := clnt.Get(ctx, apitypes.NamespacedName{Namespace: component.Namespace, Name: r.Name}, configMap); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			object = nil
+		}
+		data = datasource.ConfigMapData(configMap)
+	default:
+		return fmt.Errorf("invalid values reference kind: %s", r.Kind)
+	}
+
+	if object == nil {
+		if r.Optional {
+			r.loaded = true
+			return nil
+		}
+		return componentoperatorruntimetypes.NewRetriableError(fmt.Errorf("%s %s/%s not found", r.kind(), component.Namespace, r.Name), new(10*time.Second))
+	}
+
+	keys := []string{r.Key}
+	if r.Key == "" {
+		keys = []string{"values", "values.yaml", "values.yml"}
+	}
+	for _, key := range keys {
+		if value, ok := data[key]; ok {
+			r.value = value
+			r.digest = sha256hex(value)
+			r.loaded = true
+			return nil
+		}
+	}
+	if r.Optional {
+		r.loaded = true
+		return nil
+	}
+	if r.Key == "" {
+		return componentoperatorruntimetypes.NewRetriableError(fmt.Errorf("none of the keys values, values.yaml, values.yml found in %s %s/%s", r.kind(), component.Namespace, r.Name), new(10*time.Second))
+	}
+	return componentoperatorruntimetypes.NewRetriableError(fmt.Errorf("key %s not found in %s %s/%s", r.Key, r.kind(), component.Namespace, r.Name), new(10*time.Second))
+}
+
+// Implement the component.Reference interface.
+func (r *ValuesReference) Digest() string {
+	if !r.loaded {
+		return ""
+	}
+	return r.digest
+}
+
+// Get the content of a loaded values reference; returns nil if the reference is optional and the referenced object or key
+// does not exist, or if the component is being deleted. Calling Value() on a not-loaded values reference will panic.
+func (r *ValuesReference) Value() []byte {
+	if !r.loaded {
+		// note: this panic indicates a programmatic error on the consumer side
+		panic("access to unloaded reference")
+	}
+	return r.value
+}
+
+func (r *ValuesReference) kind() string {
+	if r.Kind == "" {
+		return string(ValuesReferenceKindSecret)
+	}
+	return string(r.Kind)
+}
+
+// ValuesMergeStrategy determines how values from different sources (valuesFrom entries, values) are merged.
+// Maps are always merged recursively, and scalars are replaced; lists are handled according to the configured strategy.
+type ValuesMergeStrategy struct {
+	// Strategy applied to lists not matched by any of the paths. Defaults to Replace.
+	Lists ListMergeStrategy `json:"lists,omitempty"`
+	// Key identifying list items if the MergeByKey strategy is used. Defaults to name.
+	// +kubebuilder:validation:MinLength=1
+	MergeKey string `json:"mergeKey,omitempty"`
+	// Strategies for the lists at specific paths.
+	// +listType=map
+	// +listMapKey=path
+	Paths []ValuesPathMergeStrategy `json:"paths,omitempty"`
+}
+
+// ValuesPathMergeStrategy determines how the lists at a specific path are merged.
+type ValuesPathMergeStrategy struct {
+	// Dotted path of the list (such as extraEnv, or containers.env); list items are not part of the path, so containers.env
+	// refers to the env lists of all items of the containers list.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
+	// +required
+	Strategy ListMergeStrategy `json:"strategy"`
+	// Key identifying list items if the MergeByKey strategy is used. Defaults to the merge key of the enclosing strategy.
+	// +kubebuilder:validation:MinLength=1
+	MergeKey string `json:"mergeKey,omitempty"`
+}
+
+// ListMergeStrategy denotes how lists are merged. Replace replaces the existing list, Append appends the items to the existing list,
+// MergeByKey merges items identified by the same value of the merge key recursively, and appends all other items.
+// +kubebuilder:validation:Enum=Replace;Append;MergeByKey
+type ListMergeStrategy string
+
+const (
+	ListMergeStrategyReplace    ListMergeStrategy = "Replace"
+	ListMergeStrategyAppend     ListMergeStrategy = "Append"
+	ListMergeStrategyMergeByKey ListMergeStrategy = "MergeByKey"
+)
+
 // Decryption settings.
 type Decryption struct {
 	// Decryption provider. Currently, the only supported value is 'sops', which is the default if the
@@ -303,9 +787,292 @@ type PostBuild struct {
 	Images []manifests.KustomizeImage `json:"images,omitempty"`
 }
 
-// Dependency models a dependency of the containing component to another Component (referenced by namespace and name).
+// GeneratorType denotes the generator used to render the source of a component.
+// If the generator is omitted, or set to auto, the helm generator is used if the source path contains a Chart.yaml file,
+// otherwise the kustomize generator is used.
+// Dependencies declared in the Chart.yaml of helm charts are taken from the charts directory (as directory or archive),
+// or resolved from their repository, which may be a file:// or relative path (within the source artifact), or refer to
+// a blueprint (blueprint://<namespace>/<name>[/<path>]) or to the source of another component (component://<namespace>/<name>[/<path>]).
+// +kubebuilder:validation:Enum=auto;helm;kustomize;plain;jsonnet;cue
+type GeneratorType string
+
+const (
+	GeneratorAuto      GeneratorType = "auto"
+	GeneratorHelm      GeneratorType = "helm"
+	GeneratorKustomize GeneratorType = "kustomize"
+	GeneratorPlain     GeneratorType = "plain"
+	GeneratorJsonnet   GeneratorType = "jsonnet"
+	GeneratorCue       GeneratorType = "cue"
+)
+
+// HelmGeneratorOptions allows to configure the helm generator. The options are ignored if the generator is auto
+// and the source path turns out to contain no helm chart.
+type HelmGeneratorOptions struct {
+	// Release name used when rendering the chart (.Release.Name). Defaults to the name of the component.
+	// +kubebuilder:validation:MaxLength=53
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	ReleaseName string `json:"releaseName,omitempty"`
+	// Whether custom resource definitions contained in the crds directory of the chart (and its subcharts) are deployed.
+	// Defaults to Include.
+	// +kubebuilder:validation:Enum=Include;Skip
+	Crds HelmCrdPolicy `json:"crds,omitempty"`
+	// Whether the values (merged with the default values of the chart) are validated against the values.schema.json
+	// file of the chart (if present). Violations make the component fail with a (non-retriable) error.
+	StrictValuesValidation bool `json:"strictValuesValidation,omitempty"`
+	// Kubernetes version exposed as .Capabilities.KubeVersion (such as v1.30.2). Defaults to the version of the target cluster.
+	// +kubebuilder:validation:Pattern=`^v?[0-9]+\.[0-9]+(\.[0-9]+)?([-+].*)?$`
+	KubeVersion string `json:"kubeVersion,omitempty"`
+	// Additional API versions exposed in .Capabilities.APIVersions, besides the ones served by the target cluster.
+	// Entries have the format <group>/<version> (or just <version> for the core group), optionally followed by /<kind>.
+	ApiVersions []string `json:"apiVersions,omitempty"`
+	// Overrides applied to the values of the component, similar to helm's --set-json flag. Overrides are applied in the given
+	// order, after values and valuesFrom have been merged.
+	Set []HelmValueOverride `json:"set,omitempty"`
+}
+
+// HelmCrdPolicy determines how custom resource definitions contained in the crds directory of a chart are handled.
+type HelmCrdPolicy string
+
+const (
+	HelmCrdPolicyInclude HelmCrdPolicy = "Include"
+	HelmCrdPolicySkip    HelmCrdPolicy = "Skip"
+)
+
+// HelmValueOverride sets the value at the given path to a (typed) value.
+type HelmValueOverride struct {
+	// Path of the value, in the notation of helm's --set flag (such as a.b[0].c); dots contained in keys can be escaped by a backslash.
+	// Intermediate maps and lists are created as needed.
+	// +required
+	Path string `json:"path"`
+	// Value to be set; may be of any type (scalar, list or object).
+	// +required
+	Value apiextensionsv1.JSON `json:"value"`
+}
+
+// PlainGeneratorOptions allows to configure the plain generator, which renders all YAML and JSON files found
+// (recursively) in the source path, without requiring a kustomization file.
+type PlainGeneratorOptions struct {
+	// Whether the files are rendered as Go templates (with sprig functions) before being parsed.
+	// The template data contains the fields .Values (the merged values of the component), .Namespace and .Name.
+	Templating bool `json:"templating,omitempty"`
+}
+
+// JsonnetGeneratorOptions allows to configure the jsonnet generator, which evaluates a jsonnet main file.
+// Imports are resolved relative to the importing file, or relative to the root of the source artifact.
+// The evaluation result may be a single object, an array of objects, or an object containing objects as field values
+// (recursively).
+type JsonnetGeneratorOptions struct {
+	// Path of the main file, relative to the source path. Defaults to main.jsonnet.
+	// +kubebuilder:validation:Pattern=`^[^/]`
+	MainFile string `json:"mainFile,omitempty"`
+	// Whether the merged values of the component are passed as top-level argument (tla) or as external variable (extVar).
+	// Defaults to tla.
+	// +kubebuilder:validation:Enum=tla;extVar
+	ValuesMode string `json:"valuesMode,omitempty"`
+	// Name of the top-level argument or external variable containing the values. Defaults to values.
+	ValuesName string `json:"valuesName,omitempty"`
+}
+
+// CueGeneratorOptions allows to configure the cue generator, which evaluates the CUE package contained in the source path.
+// The merged values of the component are unified with the field at valuesPath; if the values violate the constraints
+// defined there, the component fails with a (non-retriable) error. The objects to be deployed are taken from the field
+// at objectsPath; it may contain a single object, a list of objects, or a struct containing objects as field values (recursively).
+type CueGeneratorOptions struct {
+	// CUE path of the field the values are unified with. Defaults to values.
+	ValuesPath string `json:"valuesPath,omitempty"`
+	// CUE path of the field containing the objects. Defaults to objects.
+	ObjectsPath string `json:"objectsPath,omitempty"`
+}
+
+// Dependency models a dependency of the containing component to another object (referenced by apiVersion, kind, namespace and name);
+// if apiVersion and kind are omitted, the dependency refers to another Component. The containing component is only reconciled
+// if the referenced object is ready. Components are ready if their state is Ready; for other objects, readiness is determined
+// by the given CEL expression, or by kstatus if no expression is provided. For cluster-scoped objects, the namespace is ignored.
+// Values exported by another component can be imported into the values or the post-build substitutions of the containing component;
+// the import is resolved once the exporting component is ready, and changes of the exported values trigger a reconciliation
+// of the importing component.
+// +kubebuilder:validation:XValidation:rule="has(self.apiVersion) == has(self.kind)",message="Fields 'apiVersion' and 'kind' must be provided together"
+// +kubebuilder:validation:XValidation:rule="!has(self.kind) || !has(self.readyWhen) || self.apiVersion != 'core.cs.sap.com/v1alpha1' || self.kind != 'Component'",message="Field 'readyWhen' is not allowed for dependencies to components"
+// +kubebuilder:validation:XValidation:rule="!has(self.kind) || !has(self.imports) || self.apiVersion == 'core.cs.sap.com/v1alpha1' && self.kind == 'Component'",message="Field 'imports' is only allowed for dependencies to components"
 type Dependency struct {
 	NamespacedName `json:",inline"`
+	// API version of the referenced object; defaults to the API version of Component.
+	// +kubebuilder:validation:Pattern=`^([a-z0-9.-]+/)?v[a-z0-9]+$`
+	ApiVersion string `json:"apiVersion,omitempty"`
+	// Kind of the referenced object; defaults to Component.
+	// +kubebuilder:validation:MinLength=1
+	Kind string `json:"kind,omitempty"`
+	// CEL expression evaluating to true if the referenced object is ready; the object is available as variable 'object',
+	// for example: object.status.conditions.exists(c, c.type == 'Established' && c.status == 'True').
+	// Not allowed for dependencies to components.
+	// +kubebuilder:validation:MinLength=1
+	ReadyWhen string `json:"readyWhen,omitempty"`
+	// Values imported from the other component; only allowed for dependencies to components.
+	Imports []Import          `json:"imports,omitempty"`
+	exports map[string][]byte `json:"-"`
+	digest  string            `json:"-"`
+	loaded  bool              `json:"-"`
+}
+
+var _ component.Reference[*Component] = &Dependency{}
+
+// Implement the component.Reference interface.
+func (d *Dependency) Load(ctx context.Context, clnt client.Client, component *Component) error {
+	if d.loaded {
+		// note: this panic indicates a programmatic error on the consumer side
+		panic("reference already initialized")
+	}
+
+	if !component.DeletionTimestamp.IsZero() {
+		d.loaded = true
+		return nil
+	}
+
+	name := d.WithDefaultNamespace(component.Namespace)
+	// note: missing objects are ignored here, since missing dependencies are reported when checking the readiness of the dependency
+	if err := checkReferenceGrant(ctx, clnt, d.GroupVersionKind(), name, component); err != nil && !apimeta.IsNoMatchError(err) && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	if !d.IsComponent() || len(d.Imports) == 0 {
+		d.loaded = true
+		return nil
+	}
+
+	c := &Component{}
+	if err := clnt.Get(ctx, apitypes.NamespacedName(name), c); err != nil {
+		if apierrors.IsNotFound(err) {
+			return componentoperatorruntimetypes.NewRetriableError(fmt.Errorf("dependent component %s not found", name), new(10*time.Second))
+		}
+		return err
+	}
+
+	exports := make(map[string][]byte)
+	for _, imp := range d.Imports {
+		value, ok := c.Status.Exports[imp.Export]
+		if !ok {
+			return componentoperatorruntimetypes.NewRetriableError(fmt.Errorf("export %s not (yet) available on dependent component %s", imp.Export, name), new(10*time.Second))
+		}
+		exports[imp.Export] = value.Raw
+	}
+
+	d.exports = exports
+	d.digest = calculateDigest(exports)
+	d.loaded = true
+
+	return nil
+}
+
+// Implement the component.Reference interface.
+func (d *Dependency) Digest() string {
+	if !d.loaded {
+		return ""
+	}
+	return d.digest
+}
+
+// Get the group, version and kind of the referenced object.
+func (d *Dependency) GroupVersionKind() schema.GroupVersionKind {
+	if d.ApiVersion == "" && d.Kind == "" {
+		return GroupVersion.WithKind(KindComponent)
+	}
+	// note: the api version is validated by the schema, so the error can be ignored here
+	gv, _ := schema.ParseGroupVersion(d.ApiVersion)
+	return gv.WithKind(d.Kind)
+}
+
+// Get a reference to the object referenced by the dependency, using the specified namespace if none is set.
+func (d *Dependency) ObjectReference(namespace string) ObjectReference {
+	return ObjectReference{
+		ApiVersion: d.GroupVersionKind().GroupVersion().String(),
+		Kind:       d.GroupVersionKind().Kind,
+		Namespace:  d.WithDefaultNamespace(namespace).Namespace,
+		Name:       d.Name,
+	}
+}
+
+// Check if the dependency refers to a Component.
+func (d *Dependency) IsComponent() bool {
+	return d.GroupVersionKind() == GroupVersion.WithKind(KindComponent)
+}
+
+// Get the (JSON-encoded) value of the given export, as imported by a loaded dependency. Calling ExportedValue() on a not-loaded
+// dependency will panic.
+func (d *Dependency) ExportedValue(export string) []byte {
+	if !d.loaded {
+		// note: this panic indicates a programmatic error on the consumer side
+		panic("access to unloaded reference")
+	}
+	return d.exports[export]
+}
+
+// Import models a value imported from a dependency. The value is placed at the given path of the values, and/or provided
+// as post-build substitution variable. When used as substitution variable, string values are inserted as they are, and
+// all other values are inserted in JSON representation. Imported values have precedence over the values provided by valuesFrom
+// (or postBuild.substituteFrom), and are overridden by inline values (or postBuild.substitute).
+// +kubebuilder:validation:XValidation:rule="has(self.targetPath) || has(self.variable)",message="At least one of 'targetPath' or 'variable' must be provided"
+type Import struct {
+	// Name of the export of the dependency.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Export string `json:"export"`
+	// Dotted path (such as database.secretName) at which the value is placed in the values; dots contained in keys can be escaped by a backslash.
+	// +kubebuilder:validation:MinLength=1
+	TargetPath string `json:"targetPath,omitempty"`
+	// Name of the post-build substitution variable receiving the value.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z_][a-zA-Z0-9_]*$`
+	Variable string `json:"variable,omitempty"`
+}
+
+// Export models a value exported by a component. The value is determined by evaluating a JSONPath expression (such as .status.inventory[0].name,
+// or {.spec.namespace}) against the component itself, or against one of the objects deployed by the component. The exports are
+// evaluated whenever the component becomes ready, and published in its status. If the expression yields exactly one result,
+// this result is exported, otherwise the list of all results.
+// +kubebuilder:validation:XValidation:rule="self.from != 'Object' || has(self.objectRef)",message="Field 'objectRef' is required if from is 'Object'"
+// +kubebuilder:validation:XValidation:rule="self.from == 'Object' || !has(self.objectRef)",message="Field 'objectRef' is only allowed if from is 'Object'"
+type Export struct {
+	// Name of the export.
+	// +required
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z_][a-zA-Z0-9_.-]*$`
+	Name string `json:"name"`
+	// Source of the exported value; Component means the component itself (with paths such as .spec.namespace or .status.state),
+	// Object means the object referenced by objectRef. Defaults to Component.
+	// +kubebuilder:validation:Enum=Component;Object
+	// +kubebuilder:default=Component
+	From ExportSource `json:"from,omitempty"`
+	// Reference to an object deployed by the component; must be part of the component's inventory.
+	// The data of secrets (data, stringData) cannot be exported.
+	ObjectRef *ExportObjectReference `json:"objectRef,omitempty"`
+	// JSONPath expression (in kubectl syntax, with or without enclosing braces).
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	JsonPath string `json:"jsonPath"`
+}
+
+// ExportSource denotes the source of an exported value.
+type ExportSource string
+
+const (
+	ExportSourceComponent ExportSource = "Component"
+	ExportSourceObject    ExportSource = "Object"
+)
+
+// Reference to an object deployed by a component.
+type ExportObjectReference struct {
+	// API version of the object.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	ApiVersion string `json:"apiVersion"`
+	// Kind of the object.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Kind string `json:"kind"`
+	// Namespace of the object; defaults to the deployment namespace of the component; ignored for cluster-scoped objects.
+	Namespace string `json:"namespace,omitempty"`
+	// Name of the object.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
 }
 
 // A tuple of namespace and name.
@@ -341,8 +1108,97 @@ type ComponentStatus struct {
 	LastAttemptedRevision string                 `json:"lastAttemptedRevision,omitempty"`
 	LastAppliedDigest     string                 `json:"lastAppliedDigest,omitempty"`
 	LastAppliedRevision   string                 `json:"lastAppliedRevision,omitempty"`
+	// Values exported by the component (as declared in spec.exports), as of its last successful reconciliation.
+	Exports map[string]apiextensionsv1.JSON `json:"exports,omitempty"`
+	// Transitive dependencies of the component, ordered such that dependencies precede their dependents.
+	DependencyChain []ObjectReference `json:"dependencyChain,omitempty"`
+	// Dependency currently blocking the reconciliation of the component.
+	BlockingDependency *BlockingDependency `json:"blockingDependency,omitempty"`
+	// Depending components currently blocking the deletion of the component.
+	DeletionBlockers []DeletionBlocker `json:"deletionBlockers,omitempty"`
 }
 
+// Teardown settings of a component.
+type Teardown struct {
+	// Teardown mode. With Block (the default), the deletion of the component is blocked as long as depending components exist.
+	// With Cascade, the (transitively) depending components are deleted first, in reverse topological order: the components
+	// no other depending component depends on are deleted in parallel (as one wave); once they are gone, the next wave is deleted,
+	// and so on, until the component itself is deleted. An event is emitted per wave.
+	// Depending components in other namespaces are only deleted if they grant references from the namespace of the component
+	// (through the allow-references-from annotation); otherwise, they keep blocking the deletion.
+	// If depending components form a dependency cycle, the teardown is refused (and no depending component is deleted).
+	// +kubebuilder:validation:Enum=Block;Cascade
+	Mode TeardownMode `json:"mode,omitempty"`
+	// If true (and mode is Cascade), no further waves are started as soon as the deletion of a depending component failed.
+	StopOnFailure bool `json:"stopOnFailure,omitempty"`
+}
+
+// TeardownMode denotes how depending components are handled when a component is deleted.
+type TeardownMode string
+
+const (
+	TeardownModeBlock   TeardownMode = "Block"
+	TeardownModeCascade TeardownMode = "Cascade"
+)
+
+// DeletionBlocker describes a depending component blocking the deletion of a component.
+type DeletionBlocker struct {
+	ObjectReference `json:",inline"`
+	// Reason why the depending component is blocking; one of DependentExists, DependentPending, DependentDeleting, DependentDeletionFailed,
+	// DependentNotPermitted, DependencyCycle.
+	Reason string `json:"reason"`
+	// Teardown wave (counted from 1) in which the depending component is deleted; only set if the teardown mode is Cascade.
+	Wave int `json:"wave,omitempty"`
+}
+
+const (
+	DeletionBlockerReasonDependentExists         = "DependentExists"
+	DeletionBlockerReasonDependentPending        = "DependentPending"
+	DeletionBlockerReasonDependentDeleting       = "DependentDeleting"
+	DeletionBlockerReasonDependentDeletionFailed = "DependentDeletionFailed"
+	DeletionBlockerReasonDependentNotPermitted   = "DependentNotPermitted"
+	DeletionBlockerReasonDependencyCycle         = "DependencyCycle"
+)
+
+// Reference to an arbitrary object.
+type ObjectReference struct {
+	ApiVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// Return a beautified string representation of the ObjectReference.
+func (r ObjectReference) String() string {
+	if r.Namespace == "" {
+		return fmt.Sprintf("%s %s", r.Kind, r.Name)
+	} else {
+		return fmt.Sprintf("%s %s/%s", r.Kind, r.Namespace, r.Name)
+	}
+}
+
+// BlockingDependency describes a dependency blocking the reconciliation of a component.
+type BlockingDependency struct {
+	ObjectReference `json:",inline"`
+	// Reason why the dependency is blocking; one of DependencyNotFound, DependencyNotReady, DependencyNotSynced, DependencyCycle.
+	Reason string `json:"reason"`
+	// Human-readable details.
+	Message string `json:"message,omitempty"`
+}
+
+const (
+	// Condition type reporting whether all dependencies of a component are ready.
+	ConditionTypeDependenciesReady component.ConditionType = "DependenciesReady"
+)
+
+const (
+	DependencyReasonReady     = "DependenciesReady"
+	DependencyReasonNotFound  = "DependencyNotFound"
+	DependencyReasonNotReady  = "DependencyNotReady"
+	DependencyReasonNotSynced = "DependencyNotSynced"
+	DependencyReasonCycle     = "DependencyCycle"
+)
+
 type SourceReferenceStatus struct {
 	Artifact Artifact `json:"artifact,omitempty"`
 	Digest   string   `json:"digest,omitempty"`
@@ -353,6 +1209,8 @@ type Artifact struct {
 	Url      string `json:"url"`
 	Digest   string `json:"digest"`
 	Revision string `json:"revision"`
+	// Archive format of the artifact; if empty, the format is detected when downloading the artifact.
+	Format string `json:"format,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -450,7 +1308,36 @@ type BlueprintSpec struct {
 	Files map[string]string `json:"files,omitempty"`
 }
 
+// BlueprintStatus defines the observed state of Blueprint.
+type BlueprintStatus struct {
+	// Generation of the blueprint which was last validated.
+	ObservedGeneration int64 `json:"observedGeneration"`
+	// Digest of the blueprint's files, as used by components referencing the blueprint.
+	Digest string `json:"digest,omitempty"`
+	// Total size (in bytes) of the blueprint's files (paths and contents).
+	Size int64 `json:"size,omitempty"`
+	// Conditions; the condition of type Valid reports the result of the validation of the blueprint's files,
+	// the condition of type DigestComputed reports the computed digest in its message.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+const (
+	BlueprintConditionTypeValid          = "Valid"
+	BlueprintConditionTypeDigestComputed = "DigestComputed"
+)
+
+const (
+	BlueprintReasonValid          = "Valid"
+	BlueprintReasonInvalid        = "Invalid"
+	BlueprintReasonDigestComputed = "DigestComputed"
+)
+
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Valid",type=string,JSONPath=`.status.conditions[?(@.type=='Valid')].status`
+// +kubebuilder:printcolumn:name="Digest",type=string,JSONPath=`.status.digest`,priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +genclient
 
@@ -459,7 +1346,8 @@ type Blueprint struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec BlueprintSpec `json:"spec"`
+	Spec   BlueprintSpec   `json:"spec"`
+	Status BlueprintStatus `json:"status,omitempty"`
 }
 
 func (b *Blueprint) GetDigest() string {
@@ -0,0 +1,114 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apiruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/sap/component-operator/internal/testutil"
+)
+
+// client recording all reads (except reads of object metadata only) and all writes
+type testRecordingClient struct {
+	client.Client
+	reads  []string
+	writes []string
+}
+
+func newTestRecordingClient(t *testing.T, objects ...client.Object) *testRecordingClient {
+	scheme := runtime.NewScheme()
+	apiruntime.Must(clientgoscheme.AddToScheme(scheme))
+	apiruntime.Must(AddToScheme(scheme))
+	c := &testRecordingClient{}
+	c.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(ctx context.Context, clnt client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if _, ok := obj.(*metav1.PartialObjectMetadata); !ok {
+				c.reads = append(c.reads, key.String())
+			}
+			return clnt.Get(ctx, key, obj, opts...)
+		},
+		Patch: func(ctx context.Context, clnt client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			c.writes = append(c.writes, client.ObjectKeyFromObject(obj).String())
+			// note: server-side apply is not needed for these tests, so the patch is not passed to the fake client
+			return nil
+		},
+	}).Build()
+	return c
+}
+
+func TestSourceReferenceLoadRefusesInvalidBlueprint(t *testing.T) {
+	tests := []struct {
+		name        string
+		conditions  []metav1.Condition
+		expectedErr string
+	}{
+		{name: "valid", conditions: []metav1.Condition{{Type: BlueprintConditionTypeValid, Status: metav1.ConditionTrue, ObservedGeneration: 2}}},
+		{name: "invalid", conditions: []metav1.Condition{{Type: BlueprintConditionTypeValid, Status: metav1.ConditionFalse, ObservedGeneration: 2, Message: "bad file"}}, expectedErr: "blueprint test/blueprint is invalid: bad file"},
+		{name: "not validated", expectedErr: "blueprint test/blueprint not yet validated"},
+		{name: "outdated validation", conditions: []metav1.Condition{{Type: BlueprintConditionTypeValid, Status: metav1.ConditionTrue, ObservedGeneration: 1}}, expectedErr: "blueprint test/blueprint not yet validated"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			blueprint := &Blueprint{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "blueprint", Generation: 2},
+				Spec:       BlueprintSpec{Files: map[string]string{"Chart.yaml": "name: test\n"}},
+				Status:     BlueprintStatus{Conditions: test.conditions},
+			}
+			clnt := newTestRecordingClient(t, blueprint)
+			component := &Component{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "component"},
+				Spec: ComponentSpec{
+					SourceRef: SourceReference{Blueprint: &BlueprintReference{NamespacedName: NamespacedName{Name: "blueprint"}}},
+				},
+			}
+			err := component.Spec.SourceRef.Load(context.Background(), clnt, component)
+			if testutil.CheckError(t, err, test.expectedErr) {
+				if len(clnt.writes) > 0 || component.Status.SourceRef != nil {
+					t.Errorf("expected refused blueprint not to be used, got writes %v, source reference status %v", clnt.writes, component.Status.SourceRef)
+				}
+				return
+			}
+			if component.Status.SourceRef == nil || component.Status.SourceRef.Artifact.Digest != blueprint.GetDigest() {
+				t.Errorf("unexpected source reference status: %v", component.Status.SourceRef)
+			}
+		})
+	}
+}
+
+func TestValuesReferenceLoadOnDeletion(t *testing.T) {
+	now := metav1.NewTime(time.Now())
+	component := &Component{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "component", DeletionTimestamp: &now, Finalizers: []string{"test"}},
+		Spec: ComponentSpec{
+			ValuesFrom: []ValuesReference{{Name: "missing"}},
+		},
+	}
+	clnt := newTestRecordingClient(t)
+	reference := &component.Spec.ValuesFrom[0]
+	if err := reference.Load(context.Background(), clnt, component); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if value := reference.Value(); value != nil {
+		t.Errorf("expected no value, got %q", value)
+	}
+	if digest := reference.Digest(); digest != "" {
+		t.Errorf("expected empty digest, got %s", digest)
+	}
+	if len(clnt.reads) > 0 {
+		t.Errorf("expected no reads, got %v", clnt.reads)
+	}
+}
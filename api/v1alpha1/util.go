@@ -17,6 +17,10 @@ func equal[T comparable](x *T, y *T) bool {
 	return x == nil && y == nil || x != nil && y != nil && *x == *y
 }
 
+func equalFunc[T any](x *T, y *T, eq func(*T, *T) bool) bool {
+	return x == nil && y == nil || x != nil && y != nil && eq(x, y)
+}
+
 func sha256hex(data []byte) string {
 	sum := sha256.Sum256(data)
 	return hex.EncodeToString(sum[:])
@@ -29,3 +33,14 @@ func calculateDigest(values ...any) string {
 	}
 	return sha256hex(raw)
 }
+
+func itemsToMapping(items []KeyToPath) map[string]string {
+	if len(items) == 0 {
+		return nil
+	}
+	mapping := make(map[string]string, len(items))
+	for _, item := range items {
+		mapping[item.Key] = item.Path
+	}
+	return mapping
+}
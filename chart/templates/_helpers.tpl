@@ -49,3 +49,14 @@ Selector labels
 app.kubernetes.io/name: {{ include "component-operator.name" . }}
 app.kubernetes.io/instance: {{ .Release.Name }}
 {{- end }}
+
+{{/*
+Name of the secret containing the serving certificate of the webhooks
+*/}}
+{{- define "component-operator.webhookSecretName" -}}
+{{- if eq .Values.webhook.certificate.source "secret" }}
+{{- .Values.webhook.certificate.secret.name }}
+{{- else }}
+{{- printf "%s-webhook-tls" (include "component-operator.fullname" .) }}
+{{- end }}
+{{- end }}
@@ -0,0 +1,248 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package artifact
+
+import (
+	"container/list"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// note: entries are stored as directories named by their key; entries being filled are staged in
+// directories prefixed with stagingPrefix, and renamed (atomically) to their final name once complete
+const stagingPrefix = ".tmp-"
+
+var keyPattern = regexp.MustCompile(`^[a-z0-9]+$`)
+
+// Cache is a size-bounded on-disk cache of extracted artifacts. Entries are identified by a key
+// (which should be derived from the artifact digest); if the total size exceeds the configured maximum,
+// the least recently used entries (which are not currently in use) are evicted. Entries found in the
+// cache directory at startup are recovered.
+type Cache struct {
+	dir     string
+	maxSize int64
+	mutex   sync.Mutex
+	entries map[string]*entry
+	// least recently used entries are at the back of the list
+	lru  *list.List
+	size int64
+}
+
+type entry struct {
+	key     string
+	size    int64
+	refs    int
+	element *list.Element
+	ready   chan struct{}
+	err     error
+}
+
+// Create a new cache in the given directory (which will be created if not existing), bounded by the given size (in bytes).
+func New(dir string, maxSize int64) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	c := &Cache{
+		dir:     dir,
+		maxSize: maxSize,
+		entries: make(map[string]*entry),
+		lru:     list.New(),
+	}
+	if err := c.recover(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Acquire the entry with the given key, and return the path to its directory. If the entry does not exist, fill
+// will be called with an empty directory which has to be populated; concurrent requests for the same key wait until
+// the first one has filled the entry. The returned release function must be called once the caller is done with
+// the directory; until then, the entry will not be evicted. The content of the returned directory must not be modified.
+func (c *Cache) Acquire(key string, fill func(dir string) error) (string, func(), error) {
+	if !keyPattern.MatchString(key) {
+		return "", nil, fmt.Errorf("invalid cache key: %s", key)
+	}
+
+	c.mutex.Lock()
+	if e, ok := c.entries[key]; ok {
+		e.refs++
+		c.mutex.Unlock()
+		<-e.ready
+		if e.err != nil {
+			c.release(e)
+			return "", nil, e.err
+		}
+		c.mutex.Lock()
+		c.touch(e)
+		c.mutex.Unlock()
+		return c.path(key), func() { c.release(e) }, nil
+	}
+	e := &entry{key: key, refs: 1, ready: make(chan struct{})}
+	c.entries[key] = e
+	c.mutex.Unlock()
+
+	size, err := c.fill(key, fill)
+
+	c.mutex.Lock()
+	if err != nil {
+		e.err = err
+		delete(c.entries, key)
+	} else {
+		e.size = size
+		e.element = c.lru.PushFront(e)
+		c.size += size
+	}
+	close(e.ready)
+	c.mutex.Unlock()
+
+	if err != nil {
+		return "", nil, err
+	}
+	return c.path(key), func() { c.release(e) }, nil
+}
+
+// Create a new temporary directory within the cache directory, and return its path. The directory is not part of the
+// cache (and not accounted for its size); the caller is responsible for removing it. Leftovers are removed at startup.
+func (c *Cache) MkdirTemp() (string, error) {
+	return os.MkdirTemp(c.dir, stagingPrefix)
+}
+
+// Create a new temporary file within the cache directory, and return it. The file is not part of the cache (and not accounted
+// for its size); the caller is responsible for removing it. Leftovers are removed at startup.
+func (c *Cache) CreateTemp() (*os.File, error) {
+	return os.CreateTemp(c.dir, stagingPrefix)
+}
+
+func (c *Cache) release(e *entry) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	e.refs--
+	if e.err == nil {
+		c.evict()
+	}
+}
+
+// note: must be called with c.mutex held
+func (c *Cache) touch(e *entry) {
+	c.lru.MoveToFront(e.element)
+	// note: the modification time is used to restore the order of the entries at startup, so errors can be ignored here
+	now := time.Now()
+	_ = os.Chtimes(c.path(e.key), now, now)
+}
+
+// note: must be called with c.mutex held
+func (c *Cache) evict() {
+	for element := c.lru.Back(); element != nil && c.size > c.maxSize; {
+		e := element.Value.(*entry)
+		element = element.Prev()
+		if e.refs > 0 {
+			continue
+		}
+		c.lru.Remove(e.element)
+		delete(c.entries, e.key)
+		c.size -= e.size
+		// note: rename first, so that a partially removed entry will not be recovered at startup
+		stagingPath := filepath.Join(c.dir, stagingPrefix+e.key)
+		if err := os.Rename(c.path(e.key), stagingPath); err == nil {
+			_ = os.RemoveAll(stagingPath)
+		} else {
+			_ = os.RemoveAll(c.path(e.key))
+		}
+	}
+}
+
+func (c *Cache) fill(key string, fill func(dir string) error) (int64, error) {
+	stagingPath, err := os.MkdirTemp(c.dir, stagingPrefix)
+	if err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(stagingPath)
+	if err := fill(stagingPath); err != nil {
+		return 0, err
+	}
+	size, err := diskUsage(stagingPath)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.RemoveAll(c.path(key)); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(stagingPath, c.path(key)); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+func (c *Cache) recover() error {
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+	type recoveredEntry struct {
+		entry   *entry
+		modTime time.Time
+	}
+	var recoveredEntries []recoveredEntry
+	for _, dirEntry := range dirEntries {
+		name := dirEntry.Name()
+		if strings.HasPrefix(name, stagingPrefix) || !dirEntry.IsDir() || !keyPattern.MatchString(name) {
+			if err := os.RemoveAll(filepath.Join(c.dir, name)); err != nil {
+				return err
+			}
+			continue
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			return err
+		}
+		size, err := diskUsage(c.path(name))
+		if err != nil {
+			return err
+		}
+		e := &entry{key: name, size: size, ready: make(chan struct{})}
+		close(e.ready)
+		recoveredEntries = append(recoveredEntries, recoveredEntry{entry: e, modTime: info.ModTime()})
+	}
+	slices.SortFunc(recoveredEntries, func(x recoveredEntry, y recoveredEntry) int {
+		return x.modTime.Compare(y.modTime)
+	})
+	for _, r := range recoveredEntries {
+		r.entry.element = c.lru.PushFront(r.entry)
+		c.entries[r.entry.key] = r.entry
+		c.size += r.entry.size
+	}
+	c.evict()
+	return nil
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key)
+}
+
+func diskUsage(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sap/go-generics/slices"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
@@ -25,6 +27,10 @@ func MatchingDependency(component *operatorv1alpha1.Component) client.ListOption
 	return client.MatchingFields{dependenciesIndexKey: client.ObjectKeyFromObject(component).String()}
 }
 
+func MatchingObjectDependency(object client.Object) client.ListOption {
+	return client.MatchingFields{objectDependenciesIndexKey: objectDependencyKey(object.GetObjectKind().GroupVersionKind().GroupKind(), object.GetName())}
+}
+
 func MatchingBlueprint(blueprint *operatorv1alpha1.Blueprint) client.ListOption {
 	return client.MatchingFields{blueprintIndexKey: client.ObjectKeyFromObject(blueprint).String()}
 }
@@ -33,6 +39,14 @@ func MatchingBlueprintVersion(blueprintVersion *operatorv1alpha1.BlueprintVersio
 	return client.MatchingFields{blueprintVersionIndexKey: client.ObjectKeyFromObject(blueprintVersion).String()}
 }
 
+func MatchingConfigMap(configMap *corev1.ConfigMap) client.ListOption {
+	return client.MatchingFields{configMapIndexKey: client.ObjectKeyFromObject(configMap).String()}
+}
+
+func MatchingSecret(secret *corev1.Secret) client.ListOption {
+	return client.MatchingFields{secretIndexKey: client.ObjectKeyFromObject(secret).String()}
+}
+
 func MatchingFluxSource(source meta.FluxSource) client.ListOption {
 	indexKey := ""
 	switch source.(type) {
@@ -58,6 +72,22 @@ func HasHttpRepository() client.ListOption {
 	return client.MatchingFields{sourceTypeIndexKey: sourceTypeHttpRepository}
 }
 
+func HasOciRepository() client.ListOption {
+	return client.MatchingFields{sourceTypeIndexKey: sourceTypeOciRepository}
+}
+
+func HasGitRepository() client.ListOption {
+	return client.MatchingFields{sourceTypeIndexKey: sourceTypeGitRepository}
+}
+
+func HasConfigMap() client.ListOption {
+	return client.MatchingFields{sourceTypeIndexKey: sourceTypeConfigMap}
+}
+
+func HasSecret() client.ListOption {
+	return client.MatchingFields{sourceTypeIndexKey: sourceTypeSecret}
+}
+
 func HasFluxGitRepository() client.ListOption {
 	return client.MatchingFields{sourceTypeIndexKey: sourceTypeFluxGitRepository}
 }
@@ -81,11 +111,15 @@ func HasFluxSource() client.ListOption {
 const (
 	sourceTypeIndexKey string = ".metadata.sourceType"
 
-	dependenciesIndexKey string = ".metadata.dependencies"
+	dependenciesIndexKey       string = ".metadata.dependencies"
+	objectDependenciesIndexKey string = ".metadata.objectDependencies"
 
 	blueprintIndexKey        string = ".metadata.cs.blueprint"
 	blueprintVersionIndexKey string = ".metadata.cs.blueprintversion"
 
+	configMapIndexKey string = ".metadata.core.configMap"
+	secretIndexKey    string = ".metadata.core.secret"
+
 	gitRepositoryIndexKey string = ".metadata.flux.gitRepository"
 	ociRepositoryIndexKey string = ".metadata.flux.ociRepository"
 	bucketIndexKey        string = ".metadata.flux.bucket"
@@ -95,6 +129,10 @@ const (
 const (
 	sourceTypeBlueprint         string = "blueprint"
 	sourceTypeHttpRepository    string = "httpRepository"
+	sourceTypeOciRepository     string = "ociRepository"
+	sourceTypeGitRepository     string = "gitRepository"
+	sourceTypeConfigMap         string = "configMap"
+	sourceTypeSecret            string = "secret"
 	sourceTypeFluxGitRepository string = "fluxGitRepository"
 	sourceTypeFluxOciRepository string = "fluxOciRepository"
 	sourceTypeFluxBucket        string = "fluxBucket"
@@ -111,6 +149,9 @@ func SetupWithManager(mgr manager.Manager) error {
 	if err := mgr.GetCache().IndexField(context.TODO(), &operatorv1alpha1.Component{}, dependenciesIndexKey, indexByDependencies); err != nil {
 		return errors.Wrapf(err, "failed setting index field %s", dependenciesIndexKey)
 	}
+	if err := mgr.GetCache().IndexField(context.TODO(), &operatorv1alpha1.Component{}, objectDependenciesIndexKey, indexByObjectDependencies); err != nil {
+		return errors.Wrapf(err, "failed setting index field %s", objectDependenciesIndexKey)
+	}
 
 	if err := mgr.GetCache().IndexField(context.TODO(), &operatorv1alpha1.Component{}, blueprintIndexKey, indexByBlueprint); err != nil {
 		return errors.Wrapf(err, "failed setting index field %s", blueprintIndexKey)
@@ -119,6 +160,13 @@ func SetupWithManager(mgr manager.Manager) error {
 		return errors.Wrapf(err, "failed setting index field %s", blueprintVersionIndexKey)
 	}
 
+	if err := mgr.GetCache().IndexField(context.TODO(), &operatorv1alpha1.Component{}, configMapIndexKey, indexByConfigMap); err != nil {
+		return errors.Wrapf(err, "failed setting index field %s", configMapIndexKey)
+	}
+	if err := mgr.GetCache().IndexField(context.TODO(), &operatorv1alpha1.Component{}, secretIndexKey, indexBySecret); err != nil {
+		return errors.Wrapf(err, "failed setting index field %s", secretIndexKey)
+	}
+
 	if err := mgr.GetCache().IndexField(context.TODO(), &operatorv1alpha1.Component{}, gitRepositoryIndexKey, indexCompoonentByGitRepository); err != nil {
 		return errors.Wrapf(err, "failed setting index field %s", gitRepositoryIndexKey)
 	}
@@ -143,6 +191,18 @@ func indexBySourceType(object client.Object) []string {
 	if component.Spec.SourceRef.HttpRepository != nil {
 		return []string{sourceTypeHttpRepository}
 	}
+	if component.Spec.SourceRef.OciRepository != nil {
+		return []string{sourceTypeOciRepository}
+	}
+	if component.Spec.SourceRef.GitRepository != nil {
+		return []string{sourceTypeGitRepository}
+	}
+	if component.Spec.SourceRef.ConfigMap != nil {
+		return []string{sourceTypeConfigMap}
+	}
+	if component.Spec.SourceRef.Secret != nil {
+		return []string{sourceTypeSecret}
+	}
 	if component.Spec.SourceRef.FluxGitRepository != nil {
 		return []string{sourceTypeFluxGitRepository, sourceTypeFluxSource}
 	}
@@ -160,11 +220,28 @@ func indexBySourceType(object client.Object) []string {
 
 func indexByDependencies(object client.Object) []string {
 	component := object.(*operatorv1alpha1.Component)
-	return slices.Collect(component.Spec.Dependencies, func(dependency operatorv1alpha1.Dependency) string {
+	return slices.Collect(slices.Select(component.Spec.Dependencies, func(dependency operatorv1alpha1.Dependency) bool {
+		return dependency.IsComponent()
+	}), func(dependency operatorv1alpha1.Dependency) string {
 		return dependency.WithDefaultNamespace(component.Namespace).String()
 	})
 }
 
+// note: the namespace is not part of the key, since it is not known here whether the referenced object is namespaced;
+// consumers have to check the namespace on their own
+func indexByObjectDependencies(object client.Object) []string {
+	component := object.(*operatorv1alpha1.Component)
+	return slices.Collect(slices.Select(component.Spec.Dependencies, func(dependency operatorv1alpha1.Dependency) bool {
+		return !dependency.IsComponent()
+	}), func(dependency operatorv1alpha1.Dependency) string {
+		return objectDependencyKey(dependency.GroupVersionKind().GroupKind(), dependency.Name)
+	})
+}
+
+func objectDependencyKey(groupKind schema.GroupKind, name string) string {
+	return fmt.Sprintf("%s/%s", groupKind, name)
+}
+
 func indexByBlueprint(object client.Object) []string {
 	component := object.(*operatorv1alpha1.Component)
 	if component.Spec.SourceRef.Blueprint == nil {
@@ -187,6 +264,22 @@ func indexByBlueprintVersion(object client.Object) []string {
 	}.WithDefaultNamespace(component.Namespace).String()}
 }
 
+func indexByConfigMap(object client.Object) []string {
+	component := object.(*operatorv1alpha1.Component)
+	if component.Spec.SourceRef.ConfigMap == nil {
+		return nil
+	}
+	return []string{component.Spec.SourceRef.ConfigMap.WithDefaultNamespace(component.Namespace).String()}
+}
+
+func indexBySecret(object client.Object) []string {
+	component := object.(*operatorv1alpha1.Component)
+	if component.Spec.SourceRef.Secret == nil {
+		return nil
+	}
+	return []string{component.Spec.SourceRef.Secret.WithDefaultNamespace(component.Namespace).String()}
+}
+
 func indexCompoonentByGitRepository(object client.Object) []string {
 	component := object.(*operatorv1alpha1.Component)
 	if component.Spec.SourceRef.FluxGitRepository == nil {
@@ -8,10 +8,15 @@ package blueprint
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -22,11 +27,13 @@ import (
 
 	operatorv1alpha1 "github.com/sap/component-operator/api/v1alpha1"
 	componentcache "github.com/sap/component-operator/internal/cache/component"
+	"github.com/sap/component-operator/internal/validation"
 	"github.com/sap/component-operator/pkg/meta"
 )
 
 const (
 	reasonDeletionBlocked = "DeletionBlocked"
+	reasonInvalid         = "Invalid"
 )
 
 type ReconcilerOptions struct {
@@ -62,6 +69,9 @@ func (r *reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 				return ctrl.Result{}, err
 			}
 		}
+		if err := r.updateStatus(ctx, blueprint); err != nil {
+			return ctrl.Result{}, err
+		}
 	}
 
 	blueprintVersionList := &operatorv1alpha1.BlueprintVersionList{}
@@ -111,6 +121,45 @@ func (r *reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	}
 }
 
+// validate the files of the given blueprint, and record the result (along with the digest) in the status
+func (r *reconciler) updateStatus(ctx context.Context, blueprint *operatorv1alpha1.Blueprint) error {
+	status := blueprint.Status.DeepCopy()
+	status.ObservedGeneration = blueprint.Generation
+	status.Digest = blueprint.GetDigest()
+	status.Size = validation.BlueprintTotalSize(blueprint.Spec.Files)
+
+	warnings, errs := validation.ValidateBlueprintFiles(blueprint.Spec.Files, field.NewPath("spec", "files"))
+	validCondition := metav1.Condition{
+		Type:               operatorv1alpha1.BlueprintConditionTypeValid,
+		ObservedGeneration: blueprint.Generation,
+	}
+	if len(errs) > 0 {
+		validCondition.Status = metav1.ConditionFalse
+		validCondition.Reason = operatorv1alpha1.BlueprintReasonInvalid
+		validCondition.Message = errs.ToAggregate().Error()
+	} else {
+		validCondition.Status = metav1.ConditionTrue
+		validCondition.Reason = operatorv1alpha1.BlueprintReasonValid
+		validCondition.Message = strings.Join(append([]string{"Blueprint files are valid"}, warnings...), "; ")
+	}
+	if apimeta.SetStatusCondition(&status.Conditions, validCondition) && validCondition.Status == metav1.ConditionFalse {
+		r.eventRecorder.Eventf(blueprint, corev1.EventTypeWarning, reasonInvalid, "Blueprint is invalid: %s", validCondition.Message)
+	}
+	apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               operatorv1alpha1.BlueprintConditionTypeDigestComputed,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: blueprint.Generation,
+		Reason:             operatorv1alpha1.BlueprintReasonDigestComputed,
+		Message:            status.Digest,
+	})
+
+	if equality.Semantic.DeepEqual(status, &blueprint.Status) {
+		return nil
+	}
+	blueprint.Status = *status
+	return r.client.Status().Update(ctx, blueprint)
+}
+
 func SetupWithManager(mgr ctrl.Manager, options ReconcilerOptions) error {
 	reconciler := newReconciler(mgr.GetClient(), mgr.GetCache(), mgr.GetEventRecorderFor(options.Name))
 
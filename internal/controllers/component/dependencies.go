@@ -0,0 +1,299 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/sap/go-generics/slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/sap/component-operator-runtime/pkg/component"
+
+	operatorv1alpha1 "github.com/sap/component-operator/api/v1alpha1"
+	componentcache "github.com/sap/component-operator/internal/cache/component"
+)
+
+// maximum cost of a single evaluation of a readiness expression
+const readinessExpressionCostLimit = 1000000
+
+// watcher for the types of objects referenced by dependencies; since these types are only known at runtime, watches are
+// registered on demand; the watcher acts as source of the controller, and passes events for objects of the watched types
+// to the given handler (as generic events), which is expected to filter them, and to enqueue the affected dependents
+type dependencyWatcher struct {
+	cache   cache.Cache
+	handler handler.TypedEventHandler[client.Object, reconcile.Request]
+	mutex   sync.Mutex
+	ctx     context.Context
+	queue   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	watched map[schema.GroupVersionKind]struct{}
+}
+
+var _ source.TypedSource[reconcile.Request] = &dependencyWatcher{}
+
+func newDependencyWatcher(cache cache.Cache, handler handler.TypedEventHandler[client.Object, reconcile.Request]) *dependencyWatcher {
+	return &dependencyWatcher{
+		cache:   cache,
+		handler: handler,
+		watched: make(map[schema.GroupVersionKind]struct{}),
+	}
+}
+
+// Start implements the source.TypedSource interface; it is called by the controller (with its workqueue) before
+// the reconciliation starts
+func (w *dependencyWatcher) Start(ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.queue != nil {
+		return fmt.Errorf("dependency watcher already started")
+	}
+	w.ctx = ctx
+	w.queue = queue
+	return nil
+}
+
+// ensure that objects of the given type are watched; this is idempotent
+func (w *dependencyWatcher) watch(ctx context.Context, gvk schema.GroupVersionKind) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.queue == nil {
+		return fmt.Errorf("dependency watcher not started")
+	}
+	if _, ok := w.watched[gvk]; ok {
+		return nil
+	}
+
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(gvk)
+	informer, err := w.cache.GetInformer(ctx, object)
+	if err != nil {
+		return err
+	}
+	// note: the informer calls the event handlers sequentially, so they must not block; the handler only performs
+	// an index lookup (to find the components depending on the object), and adds the according requests to the
+	// workqueue, which never blocks; deletions are not propagated, since deleted objects can never make dependents ready
+	if _, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) {
+			w.dispatch(obj)
+		},
+		UpdateFunc: func(oldObj any, newObj any) {
+			if oldObject, ok := oldObj.(client.Object); ok {
+				if newObject, ok := newObj.(client.Object); ok && oldObject.GetResourceVersion() == newObject.GetResourceVersion() {
+					// note: periodic resyncs do not change the object, so there is nothing to propagate
+					return
+				}
+			}
+			w.dispatch(newObj)
+		},
+	}); err != nil {
+		return err
+	}
+	w.watched[gvk] = struct{}{}
+
+	return nil
+}
+
+func (w *dependencyWatcher) dispatch(obj any) {
+	if object, ok := obj.(client.Object); ok {
+		w.handler.Generic(w.ctx, event.TypedGenericEvent[client.Object]{Object: object}, w.queue)
+	}
+}
+
+var readinessPrograms sync.Map
+
+// check if the given object is ready; if an expression is given, it is evaluated (as CEL expression) against the object,
+// otherwise the readiness is determined by kstatus
+func isObjectReady(object *unstructured.Unstructured, expression string) (bool, error) {
+	if expression == "" {
+		result, err := status.Compute(object)
+		if err != nil {
+			return false, err
+		}
+		return result.Status == status.CurrentStatus, nil
+	}
+
+	program, err := getReadinessProgram(expression)
+	if err != nil {
+		return false, err
+	}
+	value, _, err := program.Eval(map[string]any{"object": object.Object})
+	if err != nil {
+		return false, fmt.Errorf("error evaluating readiness expression: %w", err)
+	}
+	ready, ok := value.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("readiness expression does not evaluate to bool")
+	}
+	return ready, nil
+}
+
+// get compiled program for the given readiness expression; compiled programs are cached
+func getReadinessProgram(expression string) (cel.Program, error) {
+	if program, ok := readinessPrograms.Load(expression); ok {
+		return program.(cel.Program), nil
+	}
+	env, err := cel.NewEnv(cel.Variable("object", cel.DynType))
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("invalid readiness expression: %w", issues.Err())
+	}
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, fmt.Errorf("invalid readiness expression: must evaluate to bool, but has type %s", ast.OutputType())
+	}
+	program, err := env.Program(ast, cel.CostLimit(readinessExpressionCostLimit))
+	if err != nil {
+		return nil, fmt.Errorf("invalid readiness expression: %w", err)
+	}
+	readinessPrograms.Store(expression, program)
+	return program, nil
+}
+
+// return the transitive dependencies of the given component, such that dependencies precede their dependents;
+// dependencies of components which do not exist (yet) are not resolved
+func resolveDependencyChain(ctx context.Context, cache cache.Cache, c *operatorv1alpha1.Component) ([]operatorv1alpha1.ObjectReference, error) {
+	var chain []operatorv1alpha1.ObjectReference
+	visited := map[operatorv1alpha1.ObjectReference]bool{componentReference(c): true}
+
+	var visit func(c *operatorv1alpha1.Component) error
+	visit = func(c *operatorv1alpha1.Component) error {
+		for _, dependency := range c.Spec.Dependencies {
+			ref := dependency.ObjectReference(c.Namespace)
+			if visited[ref] {
+				continue
+			}
+			visited[ref] = true
+			if dependency.IsComponent() {
+				d := &operatorv1alpha1.Component{}
+				if err := cache.Get(ctx, apitypes.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, d); err != nil {
+					if !apierrors.IsNotFound(err) {
+						return err
+					}
+				} else if err := visit(d); err != nil {
+					return err
+				}
+			}
+			chain = append(chain, ref)
+		}
+		return nil
+	}
+
+	if err := visit(c); err != nil {
+		return nil, err
+	}
+	return chain, nil
+}
+
+// check if the given component is part of a dependency cycle, by walking through the transitive dependents of the component
+// (using the dependency index); if a cycle is found, it is returned (starting and ending with the given component), otherwise nil
+func findDependencyCycle(ctx context.Context, cache cache.Cache, c *operatorv1alpha1.Component) ([]operatorv1alpha1.ObjectReference, error) {
+	// note: parents[x] = y means that x was found as dependent of y, i.e. x depends on y
+	parents := make(map[apitypes.NamespacedName]apitypes.NamespacedName)
+	queue := []*operatorv1alpha1.Component{c}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		componentList := &operatorv1alpha1.ComponentList{}
+		if err := cache.List(ctx, componentList, componentcache.MatchingDependency(current)); err != nil {
+			return nil, err
+		}
+		for i := range componentList.Items {
+			dependent := &componentList.Items[i]
+			if dependent.NamespacedName() == c.NamespacedName() {
+				// note: the given component depends on current, which (transitively) depends on the given component
+				cycle := []operatorv1alpha1.ObjectReference{componentReference(c)}
+				for name := current.NamespacedName(); name != c.NamespacedName(); name = parents[name] {
+					cycle = append(cycle, componentReference(&operatorv1alpha1.Component{ObjectMeta: metav1.ObjectMeta{Namespace: name.Namespace, Name: name.Name}}))
+				}
+				return append(cycle, componentReference(c)), nil
+			}
+			if _, ok := parents[dependent.NamespacedName()]; ok {
+				continue
+			}
+			parents[dependent.NamespacedName()] = current.NamespacedName()
+			queue = append(queue, dependent)
+		}
+	}
+	return nil, nil
+}
+
+func formatDependencyCycle(cycle []operatorv1alpha1.ObjectReference) string {
+	return strings.Join(slices.Collect(cycle, func(ref operatorv1alpha1.ObjectReference) string {
+		return fmt.Sprintf("%s/%s", ref.Namespace, ref.Name)
+	}), " -> ")
+}
+
+func componentReference(c *operatorv1alpha1.Component) operatorv1alpha1.ObjectReference {
+	return operatorv1alpha1.ObjectReference{
+		ApiVersion: operatorv1alpha1.GroupVersion.String(),
+		Kind:       operatorv1alpha1.KindComponent,
+		Namespace:  c.Namespace,
+		Name:       c.Name,
+	}
+}
+
+// record the given dependency as blocking dependency in the status of the given component
+func setBlockingDependency(c *operatorv1alpha1.Component, dependency operatorv1alpha1.ObjectReference, reason string, err error) {
+	c.Status.BlockingDependency = &operatorv1alpha1.BlockingDependency{
+		ObjectReference: dependency,
+		Reason:          reason,
+		Message:         err.Error(),
+	}
+	setCondition(&c.Status.Status, operatorv1alpha1.ConditionTypeDependenciesReady, component.ConditionFalse, reason, err.Error())
+}
+
+// record in the status of the given component that no dependency is blocking
+func clearBlockingDependency(c *operatorv1alpha1.Component) {
+	c.Status.BlockingDependency = nil
+	setCondition(&c.Status.Status, operatorv1alpha1.ConditionTypeDependenciesReady, component.ConditionTrue, operatorv1alpha1.DependencyReasonReady, "All dependencies are ready")
+}
+
+// set the condition of the given type; the transition time is only updated if the condition status changes
+func setCondition(status *component.Status, conditionType component.ConditionType, conditionStatus component.ConditionStatus, reason string, message string) {
+	now := metav1.Now()
+	for i := range status.Conditions {
+		condition := &status.Conditions[i]
+		if condition.Type != conditionType {
+			continue
+		}
+		if condition.Status != conditionStatus {
+			condition.LastTransitionTime = &now
+		}
+		condition.Status = conditionStatus
+		condition.Reason = reason
+		condition.Message = message
+		return
+	}
+	status.Conditions = append(status.Conditions, component.Condition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		LastTransitionTime: &now,
+		Reason:             reason,
+		Message:            message,
+	})
+}
@@ -0,0 +1,206 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/sap/component-operator-runtime/pkg/component"
+	componentoperatorruntimetypes "github.com/sap/component-operator-runtime/pkg/types"
+
+	operatorv1alpha1 "github.com/sap/component-operator/api/v1alpha1"
+)
+
+// evaluate the exports declared by the given component
+func evaluateExports(ctx context.Context, c *operatorv1alpha1.Component) (map[string]apiextensionsv1.JSON, error) {
+	if len(c.Spec.Exports) == 0 {
+		return nil, nil
+	}
+
+	// note: deployed objects live in the target cluster, so they have to be read through the target client (from the context)
+	clnt, err := component.ClientFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	exports := make(map[string]apiextensionsv1.JSON)
+	for _, export := range c.Spec.Exports {
+		var data map[string]any
+		switch export.From {
+		case operatorv1alpha1.ExportSourceComponent, "":
+			data, err = runtime.DefaultUnstructuredConverter.ToUnstructured(c)
+			if err != nil {
+				return nil, err
+			}
+		case operatorv1alpha1.ExportSourceObject:
+			if isSecretReference(export.ObjectRef) {
+				readsData, err := readsSecretData(export.JsonPath)
+				if err != nil {
+					return nil, fmt.Errorf("error evaluating export %s: %w", export.Name, err)
+				}
+				if readsData {
+					return nil, fmt.Errorf("error evaluating export %s: exporting the data of secrets is not allowed", export.Name)
+				}
+			}
+			object, err := getExportedObject(ctx, clnt, c, export.ObjectRef)
+			if err != nil {
+				return nil, fmt.Errorf("error evaluating export %s: %w", export.Name, err)
+			}
+			data = object.Object
+		default:
+			return nil, fmt.Errorf("invalid source for export %s: %s", export.Name, export.From)
+		}
+		value, err := evaluateJsonPath(export.JsonPath, data)
+		if err != nil {
+			return nil, fmt.Errorf("error evaluating export %s: %w", export.Name, err)
+		}
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("error evaluating export %s: %w", export.Name, err)
+		}
+		exports[export.Name] = apiextensionsv1.JSON{Raw: raw}
+	}
+
+	return exports, nil
+}
+
+// get the referenced object; the object must be part of the component's inventory
+func getExportedObject(ctx context.Context, clnt client.Client, c *operatorv1alpha1.Component, ref *operatorv1alpha1.ExportObjectReference) (*unstructured.Unstructured, error) {
+	if ref == nil {
+		return nil, fmt.Errorf("missing object reference")
+	}
+	gv, err := schema.ParseGroupVersion(ref.ApiVersion)
+	if err != nil {
+		return nil, err
+	}
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = c.Spec.Namespace
+	}
+	if namespace == "" {
+		namespace = c.Namespace
+	}
+
+	// note: for cluster-scoped objects, the inventory item has no namespace, so namespace must not be compared in that case
+	found := false
+	for _, item := range c.Status.Inventory {
+		if item.Group == gv.Group && item.Kind == ref.Kind && item.Name == ref.Name && (item.Namespace == "" || item.Namespace == namespace) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("object %s %s/%s is not part of the component's inventory", ref.Kind, namespace, ref.Name)
+	}
+
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(gv.WithKind(ref.Kind))
+	if err := clnt.Get(ctx, apitypes.NamespacedName{Namespace: namespace, Name: ref.Name}, object); err != nil {
+		if apimeta.IsNoMatchError(err) || apierrors.IsNotFound(err) {
+			return nil, componentoperatorruntimetypes.NewRetriableError(err, new(10*time.Second))
+		}
+		return nil, err
+	}
+	// note: exports are published in the component status, which is readable by a much wider audience than secrets usually are;
+	// so the content of secrets is removed, in case an expression reached it in a way not detected by readsSecretData()
+	if isSecretReference(ref) {
+		unstructured.RemoveNestedField(object.Object, "data")
+		unstructured.RemoveNestedField(object.Object, "stringData")
+	}
+	return object, nil
+}
+
+func isSecretReference(ref *operatorv1alpha1.ExportObjectReference) bool {
+	return ref != nil && ref.ApiVersion == "v1" && ref.Kind == "Secret"
+}
+
+// check whether the given JSONPath expression (potentially) reads the data of a secret; that is, whether it refers to
+// the data or stringData fields, uses recursive descent, or starts with a wildcard
+func readsSecretData(expression string) (bool, error) {
+	if !strings.HasPrefix(expression, "{") {
+		expression = "{" + expression + "}"
+	}
+	parser, err := jsonpath.Parse("export", expression)
+	if err != nil {
+		return false, fmt.Errorf("invalid jsonpath expression %s: %w", expression, err)
+	}
+	var walk func(node jsonpath.Node, top bool) bool
+	walk = func(node jsonpath.Node, top bool) bool {
+		switch node := node.(type) {
+		case *jsonpath.ListNode:
+			for i, n := range node.Nodes {
+				if walk(n, top && i == 0) {
+					return true
+				}
+			}
+		case *jsonpath.FieldNode:
+			return node.Value == "data" || node.Value == "stringData"
+		case *jsonpath.RecursiveNode:
+			return true
+		case *jsonpath.WildcardNode:
+			return top
+		case *jsonpath.FilterNode:
+			return walk(node.Left, false) || walk(node.Right, false)
+		case *jsonpath.UnionNode:
+			for _, n := range node.Nodes {
+				if walk(n, top) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	for _, node := range parser.Root.Nodes {
+		if walk(node, true) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// evaluate the given JSONPath expression; if there is exactly one result, it is returned as is, otherwise the list of all results is returned
+func evaluateJsonPath(expression string, data map[string]any) (any, error) {
+	if !strings.HasPrefix(expression, "{") {
+		expression = "{" + expression + "}"
+	}
+	jp := jsonpath.New("export")
+	if err := jp.Parse(expression); err != nil {
+		return nil, fmt.Errorf("invalid jsonpath expression %s: %w", expression, err)
+	}
+	results, err := jp.FindResults(data)
+	if err != nil {
+		// note: paths may e.g. not (yet) exist on the status of deployed objects, so retry later
+		return nil, componentoperatorruntimetypes.NewRetriableError(err, new(10*time.Second))
+	}
+	var values []any
+	for _, result := range results {
+		for _, value := range result {
+			values = append(values, value.Interface())
+		}
+	}
+	switch len(values) {
+	case 0:
+		return nil, componentoperatorruntimetypes.NewRetriableError(fmt.Errorf("jsonpath expression %s yields no result", expression), new(10*time.Second))
+	case 1:
+		return values[0], nil
+	default:
+		return values, nil
+	}
+}
@@ -0,0 +1,89 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sap/component-operator-runtime/pkg/component"
+
+	operatorv1alpha1 "github.com/sap/component-operator/api/v1alpha1"
+	"github.com/sap/component-operator/internal/testutil"
+)
+
+func TestReadsSecretData(t *testing.T) {
+	tests := []struct {
+		expression  string
+		expected    bool
+		expectedErr string
+	}{
+		{expression: ".metadata.name"},
+		{expression: "{.metadata.labels}"},
+		{expression: ".metadata.annotations.*"},
+		{expression: ".type"},
+		{expression: ".data", expected: true},
+		{expression: ".data.password", expected: true},
+		{expression: "{.data['tls.crt']}", expected: true},
+		{expression: "{['data']}", expected: true},
+		{expression: ".stringData.password", expected: true},
+		{expression: "{.metadata.name}{.data.password}", expected: true},
+		{expression: "..password", expected: true},
+		{expression: ".*", expected: true},
+		{expression: "{.metadata.ownerReferences[?(@.kind=='Secret')].name}"},
+		{expression: "{.metadata.ownerReferences[?(@.data)].name}", expected: true},
+		{expression: "{.metadata['name','data']}", expected: true},
+		{expression: "{.metadata.name", expectedErr: "invalid jsonpath expression"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			readsData, err := readsSecretData(test.expression)
+			if testutil.CheckError(t, err, test.expectedErr) {
+				return
+			}
+			if readsData != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, readsData)
+			}
+		})
+	}
+}
+
+func TestGetExportedObjectRemovesSecretData(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "target", Name: "credentials"},
+		Data:       map[string][]byte{"password": []byte("secret")},
+		StringData: map[string]string{"username": "admin"},
+	}
+	clnt := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(secret).Build()
+	item := &component.InventoryItem{}
+	item.Kind = "Secret"
+	item.Namespace = "target"
+	item.Name = "credentials"
+	c := &operatorv1alpha1.Component{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "component"},
+	}
+	c.Status.Inventory = []*component.InventoryItem{item}
+
+	object, err := getExportedObject(context.Background(), clnt, c, &operatorv1alpha1.ExportObjectReference{ApiVersion: "v1", Kind: "Secret", Namespace: "target", Name: "credentials"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if object.GetName() != "credentials" {
+		t.Errorf("expected secret credentials, got %s", object.GetName())
+	}
+	for _, field := range []string{"data", "stringData"} {
+		if _, found, _ := unstructured.NestedFieldNoCopy(object.Object, field); found {
+			t.Errorf("expected field %s to be removed", field)
+		}
+	}
+}
@@ -10,6 +10,9 @@ import (
 
 	"github.com/go-logr/logr"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	apitypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -20,6 +23,7 @@ import (
 
 	operatorv1alpha1 "github.com/sap/component-operator/api/v1alpha1"
 	componentcache "github.com/sap/component-operator/internal/cache/component"
+	"github.com/sap/component-operator/internal/datasource"
 	"github.com/sap/component-operator/internal/object"
 	"github.com/sap/component-operator/pkg/meta"
 )
@@ -41,8 +45,10 @@ func (h *componentHandler) Create(ctx context.Context, e event.TypedCreateEvent[
 }
 
 func (h *componentHandler) Update(ctx context.Context, e event.TypedUpdateEvent[client.Object], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	oldComponent := e.ObjectOld.(*operatorv1alpha1.Component)
 	newComponent := e.ObjectNew.(*operatorv1alpha1.Component)
 
+	// note: exports are only updated when the component becomes ready, so changed exports are propagated to importing components here as well
 	if !newComponent.IsReady() {
 		return
 	}
@@ -52,18 +58,27 @@ func (h *componentHandler) Update(ctx context.Context, e event.TypedUpdateEvent[
 		h.log.Error(err, "failed to list components matching dependency")
 		return
 	}
+	// note: depending components only need to be requeued if something changed they rely on; in particular, status-only updates
+	// of ready components (such as refreshed timestamps or inventory phases) must not fan out to all dependents
+	if !hasDependencyStateChanged(oldComponent, newComponent) {
+		dependentRequeues.WithLabelValues(requeueResultSuppressed).Add(float64(len(componentList.Items)))
+		return
+	}
 	for _, c := range componentList.Items {
-		// TODO: be more selective (i.e. queue only depending components that really need it)?
 		q.Add(reconcile.Request{NamespacedName: apitypes.NamespacedName{
 			Namespace: c.Namespace,
 			Name:      c.Name,
 		}})
 	}
+	dependentRequeues.WithLabelValues(requeueResultIssued).Add(float64(len(componentList.Items)))
 }
 
 func (h *componentHandler) Delete(ctx context.Context, e event.TypedDeleteEvent[client.Object], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
 	component := e.Object.(*operatorv1alpha1.Component)
 	for _, dependency := range component.Spec.Dependencies {
+		if !dependency.IsComponent() {
+			continue
+		}
 		q.Add(reconcile.Request{NamespacedName: apitypes.NamespacedName{
 			Namespace: dependency.WithDefaultNamespace(component.Namespace).Namespace,
 			Name:      dependency.Name,
@@ -75,6 +90,75 @@ func (h *componentHandler) Generic(ctx context.Context, e event.TypedGenericEven
 	// generic events are not expected to arrive on the watch that uses this handler, so nothing to do here
 }
 
+// check if the state of a component, which is relevant for depending components, differs between the two given versions
+// of the component; this comprises the readiness, the attempted and applied digest and revision, and the exported values
+func hasDependencyStateChanged(oldComponent *operatorv1alpha1.Component, newComponent *operatorv1alpha1.Component) bool {
+	return oldComponent.IsReady() != newComponent.IsReady() ||
+		oldComponent.Status.LastAttemptedDigest != newComponent.Status.LastAttemptedDigest ||
+		oldComponent.Status.LastAttemptedRevision != newComponent.Status.LastAttemptedRevision ||
+		oldComponent.Status.LastAppliedDigest != newComponent.Status.LastAppliedDigest ||
+		oldComponent.Status.LastAppliedRevision != newComponent.Status.LastAppliedRevision ||
+		!equality.Semantic.DeepEqual(oldComponent.Status.Exports, newComponent.Status.Exports)
+}
+
+type objectDependencyHandler struct {
+	cache cache.Cache
+	log   logr.Logger
+}
+
+func newObjectDependencyHandler(cache cache.Cache, log logr.Logger) handler.TypedEventHandler[client.Object, reconcile.Request] {
+	return &objectDependencyHandler{
+		cache: cache,
+		log:   log,
+	}
+}
+
+func (h *objectDependencyHandler) Create(ctx context.Context, e event.TypedCreateEvent[client.Object], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// create events are not expected to arrive on the watch that uses this handler, so nothing to do here
+}
+
+func (h *objectDependencyHandler) Update(ctx context.Context, e event.TypedUpdateEvent[client.Object], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// update events are not expected to arrive on the watch that uses this handler, so nothing to do here
+}
+
+func (h *objectDependencyHandler) Delete(ctx context.Context, e event.TypedDeleteEvent[client.Object], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// delete events are not expected to arrive on the watch that uses this handler, so nothing to do here
+}
+
+// note: this is called synchronously by the event handlers of the informers registered by the dependency watcher, for every object
+// of the watched types; so it must not block, and should return early for objects which are not referenced by any dependency
+func (h *objectDependencyHandler) Generic(ctx context.Context, e event.TypedGenericEvent[client.Object], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	object, ok := e.Object.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+
+	componentList := &operatorv1alpha1.ComponentList{}
+	if err := h.cache.List(ctx, componentList, componentcache.MatchingObjectDependency(object)); err != nil {
+		h.log.Error(err, "failed to list components matching object dependency")
+		return
+	}
+	for _, c := range componentList.Items {
+		for _, dependency := range c.Spec.Dependencies {
+			if dependency.IsComponent() || dependency.GroupVersionKind().GroupKind() != object.GroupVersionKind().GroupKind() || dependency.Name != object.GetName() {
+				continue
+			}
+			if object.GetNamespace() != "" && dependency.WithDefaultNamespace(c.Namespace).Namespace != object.GetNamespace() {
+				continue
+			}
+			// note: objects becoming unready are not propagated, in the same way as for component dependencies
+			if ready, err := isObjectReady(object, dependency.ReadyWhen); err != nil || !ready {
+				continue
+			}
+			q.Add(reconcile.Request{NamespacedName: apitypes.NamespacedName{
+				Namespace: c.Namespace,
+				Name:      c.Name,
+			}})
+			break
+		}
+	}
+}
+
 type blueprintHandler struct {
 	cache cache.Cache
 	log   logr.Logger
@@ -120,6 +204,98 @@ func (h *blueprintHandler) createOrUpdate(ctx context.Context, blueprint *operat
 	}
 }
 
+type configMapHandler struct {
+	cache cache.Cache
+	log   logr.Logger
+}
+
+func newConfigMapHandler(cache cache.Cache, log logr.Logger) handler.TypedEventHandler[client.Object, reconcile.Request] {
+	return &configMapHandler{
+		cache: cache,
+		log:   log,
+	}
+}
+
+func (h *configMapHandler) Create(ctx context.Context, e event.TypedCreateEvent[client.Object], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.createOrUpdate(ctx, e.Object.(*corev1.ConfigMap), q)
+}
+
+func (h *configMapHandler) Update(ctx context.Context, e event.TypedUpdateEvent[client.Object], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.createOrUpdate(ctx, e.ObjectNew.(*corev1.ConfigMap), q)
+}
+
+func (h *configMapHandler) Delete(ctx context.Context, e event.TypedDeleteEvent[client.Object], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// no need to queue components if config map is deleted (reconciliation of the component would anyway fail)
+}
+
+func (h *configMapHandler) Generic(ctx context.Context, e event.TypedGenericEvent[client.Object], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// generic events are not expected to arrive on the watch that uses this handler, so nothing to do here
+}
+
+func (h *configMapHandler) createOrUpdate(ctx context.Context, configMap *corev1.ConfigMap, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	componentList := &operatorv1alpha1.ComponentList{}
+	if err := h.cache.List(ctx, componentList, componentcache.MatchingConfigMap(configMap)); err != nil {
+		h.log.Error(err, "failed to list components matching config map")
+		return
+	}
+	for _, c := range componentList.Items {
+		// note: the digest depends on the key-to-path mapping of the individual component
+		if files, err := c.Spec.SourceRef.ConfigMap.GetFiles(configMap); err == nil && c.IsReady() && c.Status.LastAttemptedDigest == datasource.CalculateDigest(files) {
+			continue
+		}
+		q.Add(reconcile.Request{NamespacedName: apitypes.NamespacedName{
+			Namespace: c.Namespace,
+			Name:      c.Name,
+		}})
+	}
+}
+
+type secretHandler struct {
+	cache cache.Cache
+	log   logr.Logger
+}
+
+func newSecretHandler(cache cache.Cache, log logr.Logger) handler.TypedEventHandler[client.Object, reconcile.Request] {
+	return &secretHandler{
+		cache: cache,
+		log:   log,
+	}
+}
+
+func (h *secretHandler) Create(ctx context.Context, e event.TypedCreateEvent[client.Object], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.createOrUpdate(ctx, e.Object.(*corev1.Secret), q)
+}
+
+func (h *secretHandler) Update(ctx context.Context, e event.TypedUpdateEvent[client.Object], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.createOrUpdate(ctx, e.ObjectNew.(*corev1.Secret), q)
+}
+
+func (h *secretHandler) Delete(ctx context.Context, e event.TypedDeleteEvent[client.Object], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// no need to queue components if secret is deleted (reconciliation of the component would anyway fail)
+}
+
+func (h *secretHandler) Generic(ctx context.Context, e event.TypedGenericEvent[client.Object], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// generic events are not expected to arrive on the watch that uses this handler, so nothing to do here
+}
+
+func (h *secretHandler) createOrUpdate(ctx context.Context, secret *corev1.Secret, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	componentList := &operatorv1alpha1.ComponentList{}
+	if err := h.cache.List(ctx, componentList, componentcache.MatchingSecret(secret)); err != nil {
+		h.log.Error(err, "failed to list components matching secret")
+		return
+	}
+	for _, c := range componentList.Items {
+		// note: the digest depends on the key-to-path mapping of the individual component
+		if files, err := c.Spec.SourceRef.Secret.GetFiles(secret); err == nil && c.IsReady() && c.Status.LastAttemptedDigest == datasource.CalculateDigest(files) {
+			continue
+		}
+		q.Add(reconcile.Request{NamespacedName: apitypes.NamespacedName{
+			Namespace: c.Namespace,
+			Name:      c.Name,
+		}})
+	}
+}
+
 type fluxSourceHandler struct {
 	cache cache.Cache
 	log   logr.Logger
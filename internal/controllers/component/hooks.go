@@ -13,7 +13,10 @@ import (
 	"github.com/pkg/errors"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -39,45 +42,125 @@ func makeFuncPostRead() component.HookFunc[*operatorv1alpha1.Component] {
 	}
 }
 
-func makeFuncPreReconcile(cache cache.Cache) component.HookFunc[*operatorv1alpha1.Component] {
+func makeFuncPreReconcile(cache cache.Cache, watcher *dependencyWatcher) component.HookFunc[*operatorv1alpha1.Component] {
 	return func(ctx context.Context, clnt client.Client, component *operatorv1alpha1.Component) error {
 		// note: it is crucial to set status.lastAttemptedDigest and status.lastAttemptedRevision here (in pre-reconcile), since generators
 		// might fetch the component from their context, relying on the fields being already updated
 		component.Status.LastAttemptedDigest = component.Spec.SourceRef.Artifact().Digest
 		component.Status.LastAttemptedRevision = component.Spec.SourceRef.Artifact().Revision
+
+		chain, err := resolveDependencyChain(ctx, cache, component)
+		if err != nil {
+			return err
+		}
+		component.Status.DependencyChain = chain
+
+		cycle, err := findDependencyCycle(ctx, cache, component)
+		if err != nil {
+			return err
+		}
+		if len(cycle) > 0 {
+			err := fmt.Errorf("dependency cycle detected: %s", formatDependencyCycle(cycle))
+			setBlockingDependency(component, cycle[1], operatorv1alpha1.DependencyReasonCycle, err)
+			// note: the cycle can only be resolved by changing one of the involved components, which will trigger a reconciliation anyway
+			return componentoperatorruntimetypes.NewRetriableError(err, new(10*time.Minute))
+		}
+
 		for _, dependency := range component.Spec.Dependencies {
-			c := &operatorv1alpha1.Component{}
-			if err := cache.Get(ctx, apitypes.NamespacedName(dependency.WithDefaultNamespace(component.Namespace)), c); err != nil {
-				if apierrors.IsNotFound(err) {
-					return componentoperatorruntimetypes.NewRetriableError(errors.Wrapf(err, "dependent component %s not found", dependency), nil)
+			reason, err := checkDependency(ctx, cache, watcher, component, &dependency)
+			if err != nil {
+				if reason != "" {
+					setBlockingDependency(component, dependency.ObjectReference(component.Namespace), reason, err)
 				}
 				return err
 			}
-			if c.Spec.SourceRef.Equals(&component.Spec.SourceRef) && (c.Status.LastAttemptedDigest == "" || c.Status.LastAttemptedDigest != component.Status.LastAttemptedDigest || c.Status.LastAttemptedRevision == "" || c.Status.LastAttemptedRevision != component.Status.LastAttemptedRevision) {
-				return componentoperatorruntimetypes.NewRetriableError(fmt.Errorf("dependent component %s not synced", dependency), nil)
-			}
-			if !c.IsReady() {
-				return componentoperatorruntimetypes.NewRetriableError(fmt.Errorf("dependent component %s not ready", dependency), nil)
-			}
 		}
+
+		clearBlockingDependency(component)
 		return nil
 	}
 }
 
+// check if the given dependency exists and is ready; if not, a non-empty reason is returned (along with a non-nil error)
+func checkDependency(ctx context.Context, cache cache.Cache, watcher *dependencyWatcher, component *operatorv1alpha1.Component, dependency *operatorv1alpha1.Dependency) (string, error) {
+	if !dependency.IsComponent() {
+		return checkObjectDependency(ctx, cache, watcher, component, dependency)
+	}
+	c := &operatorv1alpha1.Component{}
+	if err := cache.Get(ctx, apitypes.NamespacedName(dependency.WithDefaultNamespace(component.Namespace)), c); err != nil {
+		if apierrors.IsNotFound(err) {
+			return operatorv1alpha1.DependencyReasonNotFound, componentoperatorruntimetypes.NewRetriableError(errors.Wrapf(err, "dependent component %s not found", dependency), nil)
+		}
+		return "", err
+	}
+	if c.Spec.SourceRef.Equals(&component.Spec.SourceRef) && (c.Status.LastAttemptedDigest == "" || c.Status.LastAttemptedDigest != component.Status.LastAttemptedDigest || c.Status.LastAttemptedRevision == "" || c.Status.LastAttemptedRevision != component.Status.LastAttemptedRevision) {
+		return operatorv1alpha1.DependencyReasonNotSynced, componentoperatorruntimetypes.NewRetriableError(fmt.Errorf("dependent component %s not synced", dependency), nil)
+	}
+	if !c.IsReady() {
+		return operatorv1alpha1.DependencyReasonNotReady, componentoperatorruntimetypes.NewRetriableError(fmt.Errorf("dependent component %s not ready", dependency), nil)
+	}
+	return "", nil
+}
+
+// check if the object referenced by the given (non-component) dependency exists and is ready; if not, a non-empty reason is returned
+// (along with a non-nil error)
+func checkObjectDependency(ctx context.Context, cache cache.Cache, watcher *dependencyWatcher, component *operatorv1alpha1.Component, dependency *operatorv1alpha1.Dependency) (string, error) {
+	gvk := dependency.GroupVersionKind()
+	name := dependency.WithDefaultNamespace(component.Namespace)
+	// note: the watch has to be registered before reading the object, in order to not miss any subsequent changes
+	if err := watcher.watch(ctx, gvk); err != nil {
+		if apimeta.IsNoMatchError(err) {
+			return operatorv1alpha1.DependencyReasonNotFound, componentoperatorruntimetypes.NewRetriableError(errors.Wrapf(err, "type of dependent object %s %s not found", gvk.Kind, name), new(10*time.Second))
+		}
+		return "", err
+	}
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(gvk)
+	if err := cache.Get(ctx, apitypes.NamespacedName(name), object); err != nil {
+		if apierrors.IsNotFound(err) {
+			return operatorv1alpha1.DependencyReasonNotFound, componentoperatorruntimetypes.NewRetriableError(errors.Wrapf(err, "dependent object %s %s not found", gvk.Kind, name), nil)
+		}
+		return "", err
+	}
+	ready, err := isObjectReady(object, dependency.ReadyWhen)
+	if err != nil {
+		return "", errors.Wrapf(err, "error checking readiness of dependent object %s %s", gvk.Kind, name)
+	}
+	if !ready {
+		return operatorv1alpha1.DependencyReasonNotReady, componentoperatorruntimetypes.NewRetriableError(fmt.Errorf("dependent object %s %s not ready", gvk.Kind, name), nil)
+	}
+	return "", nil
+}
+
 func makeFuncPostReconcile() component.HookFunc[*operatorv1alpha1.Component] {
 	return func(ctx context.Context, clnt client.Client, component *operatorv1alpha1.Component) error {
 		component.Status.LastAppliedDigest = component.Status.LastAttemptedDigest
 		component.Status.LastAppliedRevision = component.Status.LastAttemptedRevision
+		exports, err := evaluateExports(ctx, component)
+		if err != nil {
+			return err
+		}
+		component.Status.Exports = exports
 		return nil
 	}
 }
 
-func makeFuncPreDelete(cache cache.Cache) component.HookFunc[*operatorv1alpha1.Component] {
+func makeFuncPreDelete(cache cache.Cache, recorder events.EventRecorder) component.HookFunc[*operatorv1alpha1.Component] {
 	return func(ctx context.Context, clnt client.Client, component *operatorv1alpha1.Component) error {
+		if component.Spec.Teardown != nil && component.Spec.Teardown.Mode == operatorv1alpha1.TeardownModeCascade {
+			return teardownDependents(ctx, cache, clnt, recorder, component)
+		}
 		componentList := &operatorv1alpha1.ComponentList{}
 		if err := cache.List(ctx, componentList, componentcache.MatchingDependency(component)); err != nil {
 			return err
 		}
+		component.Status.DeletionBlockers = nil
+		for _, c := range componentList.Items {
+			component.Status.DeletionBlockers = append(component.Status.DeletionBlockers, operatorv1alpha1.DeletionBlocker{
+				ObjectReference: componentReference(&c),
+				Reason:          operatorv1alpha1.DeletionBlockerReasonDependentExists,
+			})
+		}
 		if len(componentList.Items) == 0 {
 			return nil
 		} else if len(componentList.Items) == 1 {
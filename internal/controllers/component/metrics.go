@@ -0,0 +1,35 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "component_operator"
+	metricsSubsystem = "dependents"
+)
+
+const (
+	requeueResultIssued     = "issued"
+	requeueResultSuppressed = "suppressed"
+)
+
+var (
+	dependentRequeues = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "requeues_total",
+		Help:      "Number of requeues of depending components caused by updates of their dependencies, by result (issued or suppressed)",
+	}, []string{"result"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(dependentRequeues)
+}
@@ -6,8 +6,11 @@ SPDX-License-Identifier: Apache-2.0
 package component
 
 import (
+	"time"
+
 	"github.com/pkg/errors"
 
+	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -20,17 +23,29 @@ import (
 	"github.com/sap/component-operator-runtime/pkg/reconciler"
 
 	operatorv1alpha1 "github.com/sap/component-operator/api/v1alpha1"
+	artifactcache "github.com/sap/component-operator/internal/cache/artifact"
 	"github.com/sap/component-operator/internal/generator"
 )
 
 type ReconcilerOptions struct {
-	Name                    string
-	DefaultServiceAccount   string
-	MaxConcurrentReconciles int
-	EventsAddress           string
+	Name                     string
+	DefaultServiceAccount    string
+	MaxConcurrentReconciles  int
+	EventsAddress            string
+	ArtifactCacheDirectory   string
+	ArtifactCacheSize        int64
+	ArchiveMaxSize           int64
+	ArchiveMaxEntries        int
+	ArchiveMaxFileSize       int64
+	GeneratorCacheTTL        time.Duration
+	GeneratorCacheMaxEntries int
+	GeneratorCacheMaxSize    int64
+	NoCrossNamespaceRefs     bool
 }
 
 func SetupWithManager(mgr manager.Manager, options ReconcilerOptions) (*component.Reconciler[*operatorv1alpha1.Component], error) {
+	dependencyWatcher := newDependencyWatcher(mgr.GetCache(), newObjectDependencyHandler(mgr.GetCache(), mgr.GetLogger()))
+
 	blder := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(controller.Options{MaxConcurrentReconciles: options.MaxConcurrentReconciles}).
 		Watches(
@@ -39,6 +54,12 @@ func SetupWithManager(mgr manager.Manager, options ReconcilerOptions) (*componen
 		Watches(
 			&operatorv1alpha1.Blueprint{},
 			newBlueprintHandler(mgr.GetCache(), mgr.GetLogger())).
+		Watches(
+			&corev1.ConfigMap{},
+			newConfigMapHandler(mgr.GetCache(), mgr.GetLogger())).
+		Watches(
+			&corev1.Secret{},
+			newSecretHandler(mgr.GetCache(), mgr.GetLogger())).
 		Watches(
 			&fluxsourcev1.GitRepository{},
 			newFluxSourceHandler(mgr.GetCache(), mgr.GetLogger())).
@@ -50,9 +71,24 @@ func SetupWithManager(mgr manager.Manager, options ReconcilerOptions) (*componen
 			newFluxSourceHandler(mgr.GetCache(), mgr.GetLogger())).
 		Watches(
 			&fluxsourcev1.HelmChart{},
-			newFluxSourceHandler(mgr.GetCache(), mgr.GetLogger()))
+			newFluxSourceHandler(mgr.GetCache(), mgr.GetLogger())).
+		WatchesRawSource(dependencyWatcher)
+
+	artifactCache, err := artifactcache.New(options.ArtifactCacheDirectory, options.ArtifactCacheSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "error initializing artifact cache")
+	}
 
-	resourceGenerator, err := generator.NewGenerator(mgr.GetClient())
+	resourceGenerator, err := generator.NewGenerator(mgr.GetClient(), generator.Options{
+		ArtifactCache:        artifactCache,
+		ArchiveMaxSize:       options.ArchiveMaxSize,
+		ArchiveMaxEntries:    options.ArchiveMaxEntries,
+		ArchiveMaxFileSize:   options.ArchiveMaxFileSize,
+		CacheTTL:             options.GeneratorCacheTTL,
+		CacheMaxEntries:      options.GeneratorCacheMaxEntries,
+		CacheMaxSize:         options.GeneratorCacheMaxSize,
+		NoCrossNamespaceRefs: options.NoCrossNamespaceRefs,
+	})
 	if err != nil {
 		return nil, errors.Wrap(err, "error initializing resource generator")
 	}
@@ -79,11 +115,11 @@ func SetupWithManager(mgr manager.Manager, options ReconcilerOptions) (*componen
 	).WithPostReadHook(
 		makeFuncPostRead(),
 	).WithPreReconcileHook(
-		makeFuncPreReconcile(mgr.GetCache()),
+		makeFuncPreReconcile(mgr.GetCache(), dependencyWatcher),
 	).WithPostReconcileHook(
 		makeFuncPostReconcile(),
 	).WithPreDeleteHook(
-		makeFuncPreDelete(mgr.GetCache()),
+		makeFuncPreDelete(mgr.GetCache(), mgr.GetEventRecorder(options.Name)),
 	)
 
 	if err := reconciler.SetupWithManagerAndBuilder(mgr, blder); err != nil {
@@ -0,0 +1,236 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sap/go-generics/slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/sap/component-operator-runtime/pkg/component"
+	componentoperatorruntimetypes "github.com/sap/component-operator-runtime/pkg/types"
+
+	operatorv1alpha1 "github.com/sap/component-operator/api/v1alpha1"
+	componentcache "github.com/sap/component-operator/internal/cache/component"
+	"github.com/sap/component-operator/internal/reference"
+)
+
+// delete the transitive dependents of the given component in reverse topological order; returns nil if there are no
+// dependents left, and a retriable error otherwise; the remaining dependents are reported in the status of the given component;
+// dependents in other namespaces are only deleted if they grant references from the namespace of the given component
+func teardownDependents(ctx context.Context, cache cache.Cache, clnt client.Client, recorder events.EventRecorder, c *operatorv1alpha1.Component) error {
+	dependents, err := listTransitiveDependents(ctx, cache, c)
+	if err != nil {
+		return err
+	}
+	if len(dependents) == 0 {
+		c.Status.DeletionBlockers = nil
+		return nil
+	}
+
+	waves, cyclic := computeTeardownWaves(dependents)
+	if len(cyclic) > 0 {
+		return refuseTeardown(ctx, cache, recorder, c, dependents, cyclic)
+	}
+
+	var blockers []operatorv1alpha1.DeletionBlocker
+	var failed []*operatorv1alpha1.Component
+	var forbidden []*operatorv1alpha1.Component
+	for i, wave := range waves {
+		for _, d := range wave {
+			reason := operatorv1alpha1.DeletionBlockerReasonDependentPending
+			if d.DeletionTimestamp.IsZero() && !reference.IsGranted(d, c.Namespace) {
+				reason = operatorv1alpha1.DeletionBlockerReasonDependentNotPermitted
+				forbidden = append(forbidden, d)
+			} else if !d.DeletionTimestamp.IsZero() {
+				if d.Status.State == component.StateError {
+					reason = operatorv1alpha1.DeletionBlockerReasonDependentDeletionFailed
+					failed = append(failed, d)
+				} else {
+					reason = operatorv1alpha1.DeletionBlockerReasonDependentDeleting
+				}
+			}
+			blockers = append(blockers, operatorv1alpha1.DeletionBlocker{
+				ObjectReference: componentReference(d),
+				Reason:          reason,
+				Wave:            i + 1,
+			})
+		}
+	}
+	c.Status.DeletionBlockers = blockers
+
+	if len(failed) > 0 && c.Spec.Teardown.StopOnFailure {
+		err := fmt.Errorf("teardown stopped, since deletion of depending component %s failed", failed[0].NamespacedName())
+		recorder.Eventf(c, nil, corev1.EventTypeWarning, "TeardownStopped", "Teardown", "%s", err)
+		return componentoperatorruntimetypes.NewRetriableError(err, nil)
+	}
+
+	// note: the first wave consists of the dependents which no other remaining dependent depends on; they can be deleted in parallel
+	var deleted []*operatorv1alpha1.Component
+	for _, d := range waves[0] {
+		if !d.DeletionTimestamp.IsZero() || !reference.IsGranted(d, c.Namespace) {
+			continue
+		}
+		if err := clnt.Delete(ctx, d); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		deleted = append(deleted, d)
+	}
+	if len(deleted) > 0 {
+		recorder.Eventf(c, nil, corev1.EventTypeNormal, "TeardownWave", "Teardown", "Deleting depending components %s (%d wave(s) remaining)", formatComponentNames(deleted), len(waves)-1)
+	}
+
+	if len(forbidden) > 0 {
+		return componentoperatorruntimetypes.NewRetriableError(fmt.Errorf("deletion blocked by %d depending component(s); depending components %s in other namespaces must be deleted manually, or grant references from namespace %s", len(dependents), formatComponentNames(forbidden), c.Namespace), new(10*time.Second))
+	}
+	return componentoperatorruntimetypes.NewRetriableError(fmt.Errorf("deletion blocked by %d depending component(s) (teardown in progress)", len(dependents)), new(10*time.Second))
+}
+
+// refuse the teardown of the dependents of the given component, since some of them are part of (or depend on) a dependency cycle;
+// deleting them would never complete, since each component of the cycle waits for the deletion of its dependents; the remaining
+// dependents are reported in the status of the given component, and no dependent is deleted
+func refuseTeardown(ctx context.Context, cache cache.Cache, recorder events.EventRecorder, c *operatorv1alpha1.Component, dependents []*operatorv1alpha1.Component, cyclic []*operatorv1alpha1.Component) error {
+	var cycle []operatorv1alpha1.ObjectReference
+	for _, d := range cyclic {
+		var err error
+		cycle, err = findDependencyCycle(ctx, cache, d)
+		if err != nil {
+			return err
+		}
+		if len(cycle) > 0 {
+			break
+		}
+	}
+
+	isCyclic := make(map[apitypes.NamespacedName]bool)
+	for _, d := range cyclic {
+		isCyclic[d.NamespacedName()] = true
+	}
+	var blockers []operatorv1alpha1.DeletionBlocker
+	for _, d := range dependents {
+		reason := operatorv1alpha1.DeletionBlockerReasonDependentExists
+		if isCyclic[d.NamespacedName()] {
+			reason = operatorv1alpha1.DeletionBlockerReasonDependencyCycle
+		}
+		blockers = append(blockers, operatorv1alpha1.DeletionBlocker{
+			ObjectReference: componentReference(d),
+			Reason:          reason,
+		})
+	}
+	c.Status.DeletionBlockers = blockers
+
+	var err error
+	if len(cycle) > 0 {
+		err = fmt.Errorf("teardown refused, since depending components form a dependency cycle: %s", formatDependencyCycle(cycle))
+	} else {
+		// note: the cycle is not visible in the cache (anymore); the next attempt will sort this out
+		err = fmt.Errorf("teardown refused, since depending components %s form a dependency cycle", formatComponentNames(cyclic))
+	}
+	recorder.Eventf(c, nil, corev1.EventTypeWarning, "TeardownRefused", "Teardown", "%s", err)
+	// note: the cycle can only be resolved by changing one of the involved components, which will trigger a reconciliation anyway
+	return componentoperatorruntimetypes.NewRetriableError(err, new(10*time.Minute))
+}
+
+// return the components (transitively) depending on the given component
+func listTransitiveDependents(ctx context.Context, cache cache.Cache, c *operatorv1alpha1.Component) ([]*operatorv1alpha1.Component, error) {
+	var dependents []*operatorv1alpha1.Component
+	visited := map[apitypes.NamespacedName]bool{c.NamespacedName(): true}
+	queue := []*operatorv1alpha1.Component{c}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		componentList := &operatorv1alpha1.ComponentList{}
+		if err := cache.List(ctx, componentList, componentcache.MatchingDependency(current)); err != nil {
+			return nil, err
+		}
+		for i := range componentList.Items {
+			dependent := &componentList.Items[i]
+			if visited[dependent.NamespacedName()] {
+				continue
+			}
+			visited[dependent.NamespacedName()] = true
+			dependents = append(dependents, dependent)
+			queue = append(queue, dependent)
+		}
+	}
+	return dependents, nil
+}
+
+// partition the given components into waves, in reverse topological order: the first wave contains the components which no other
+// of the given components depends on, the second wave the components which only components of the first wave depend on, and so on;
+// components which cannot be assigned to a wave (since they are part of a dependency cycle, or a cycle depends on them) are returned
+// separately
+func computeTeardownWaves(components []*operatorv1alpha1.Component) ([][]*operatorv1alpha1.Component, []*operatorv1alpha1.Component) {
+	byName := make(map[apitypes.NamespacedName]*operatorv1alpha1.Component)
+	for _, c := range components {
+		byName[c.NamespacedName()] = c
+	}
+	// note: dependentCount[x] is the number of the given components directly depending on x
+	dependentCount := make(map[apitypes.NamespacedName]int)
+	for _, c := range components {
+		for _, name := range componentDependencyNames(c) {
+			if _, ok := byName[name]; ok {
+				dependentCount[name]++
+			}
+		}
+	}
+
+	var waves [][]*operatorv1alpha1.Component
+	remaining := components
+	for len(remaining) > 0 {
+		var wave, rest []*operatorv1alpha1.Component
+		for _, c := range remaining {
+			if dependentCount[c.NamespacedName()] == 0 {
+				wave = append(wave, c)
+			} else {
+				rest = append(rest, c)
+			}
+		}
+		if len(wave) == 0 {
+			return waves, rest
+		}
+		for _, c := range wave {
+			for _, name := range componentDependencyNames(c) {
+				if _, ok := byName[name]; ok {
+					dependentCount[name]--
+				}
+			}
+		}
+		waves = append(waves, wave)
+		remaining = rest
+	}
+	return waves, nil
+}
+
+// return the names of the components the given component directly depends on
+func componentDependencyNames(c *operatorv1alpha1.Component) []apitypes.NamespacedName {
+	var names []apitypes.NamespacedName
+	for _, dependency := range c.Spec.Dependencies {
+		if dependency.IsComponent() {
+			names = append(names, apitypes.NamespacedName(dependency.WithDefaultNamespace(c.Namespace)))
+		}
+	}
+	return names
+}
+
+func formatComponentNames(components []*operatorv1alpha1.Component) string {
+	return strings.Join(slices.Collect(components, func(c *operatorv1alpha1.Component) string {
+		return c.NamespacedName().String()
+	}), ", ")
+}
@@ -0,0 +1,149 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1alpha1 "github.com/sap/component-operator/api/v1alpha1"
+)
+
+// create a component from a spec like ns/name, or name (in namespace test), depending on the given components
+func newTestTeardownComponent(name string, dependencies ...string) *operatorv1alpha1.Component {
+	namespace := "test"
+	if ns, n, ok := strings.Cut(name, "/"); ok {
+		namespace, name = ns, n
+	}
+	c := &operatorv1alpha1.Component{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	for _, dependency := range dependencies {
+		ref := operatorv1alpha1.Dependency{}
+		if ns, n, ok := strings.Cut(dependency, "/"); ok {
+			ref.Namespace, ref.Name = ns, n
+		} else {
+			ref.Name = dependency
+		}
+		c.Spec.Dependencies = append(c.Spec.Dependencies, ref)
+	}
+	return c
+}
+
+func TestComputeTeardownWaves(t *testing.T) {
+	tests := []struct {
+		name           string
+		components     []*operatorv1alpha1.Component
+		expectedWaves  [][]string
+		expectedCyclic []string
+	}{
+		{
+			name: "no components",
+		},
+		{
+			name:          "independent components",
+			components:    []*operatorv1alpha1.Component{newTestTeardownComponent("a", "root"), newTestTeardownComponent("b", "root")},
+			expectedWaves: [][]string{{"test/a", "test/b"}},
+		},
+		{
+			name: "chain",
+			components: []*operatorv1alpha1.Component{
+				newTestTeardownComponent("a", "root"),
+				newTestTeardownComponent("b", "a"),
+				newTestTeardownComponent("c", "b"),
+			},
+			expectedWaves: [][]string{{"test/c"}, {"test/b"}, {"test/a"}},
+		},
+		{
+			name: "diamond",
+			components: []*operatorv1alpha1.Component{
+				newTestTeardownComponent("a", "root"),
+				newTestTeardownComponent("b", "a"),
+				newTestTeardownComponent("c", "a"),
+				newTestTeardownComponent("d", "b", "c"),
+			},
+			expectedWaves: [][]string{{"test/d"}, {"test/b", "test/c"}, {"test/a"}},
+		},
+		{
+			name: "components in other namespaces",
+			components: []*operatorv1alpha1.Component{
+				newTestTeardownComponent("a", "root"),
+				newTestTeardownComponent("other/b", "test/a"),
+				newTestTeardownComponent("other/c", "b"),
+			},
+			expectedWaves: [][]string{{"other/c"}, {"other/b"}, {"test/a"}},
+		},
+		{
+			name: "same name in other namespace",
+			components: []*operatorv1alpha1.Component{
+				newTestTeardownComponent("a", "root"),
+				newTestTeardownComponent("other/a", "test/a"),
+				newTestTeardownComponent("other/b", "a"),
+			},
+			expectedWaves: [][]string{{"other/b"}, {"other/a"}, {"test/a"}},
+		},
+		{
+			name: "dependencies outside of the given components",
+			components: []*operatorv1alpha1.Component{
+				newTestTeardownComponent("a", "root", "unrelated"),
+				newTestTeardownComponent("b", "a", "other/unrelated"),
+			},
+			expectedWaves: [][]string{{"test/b"}, {"test/a"}},
+		},
+		{
+			name: "cycle",
+			components: []*operatorv1alpha1.Component{
+				newTestTeardownComponent("a", "root", "b"),
+				newTestTeardownComponent("b", "a"),
+			},
+			expectedCyclic: []string{"test/a", "test/b"},
+		},
+		{
+			name: "cycle with dependents",
+			components: []*operatorv1alpha1.Component{
+				newTestTeardownComponent("a", "root"),
+				newTestTeardownComponent("b", "a", "c"),
+				newTestTeardownComponent("c", "b"),
+				newTestTeardownComponent("d", "c"),
+				newTestTeardownComponent("e", "root"),
+			},
+			expectedWaves:  [][]string{{"test/d", "test/e"}},
+			expectedCyclic: []string{"test/a", "test/b", "test/c"},
+		},
+		{
+			name: "self-dependency",
+			components: []*operatorv1alpha1.Component{
+				newTestTeardownComponent("a", "root", "a"),
+			},
+			expectedCyclic: []string{"test/a"},
+		},
+	}
+
+	names := func(components []*operatorv1alpha1.Component) []string {
+		var result []string
+		for _, c := range components {
+			result = append(result, c.NamespacedName().String())
+		}
+		return result
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			waves, cyclic := computeTeardownWaves(test.components)
+			var waveNames [][]string
+			for _, wave := range waves {
+				waveNames = append(waveNames, names(wave))
+			}
+			if !reflect.DeepEqual(waveNames, test.expectedWaves) {
+				t.Errorf("expected waves %v, got %v", test.expectedWaves, waveNames)
+			}
+			if cyclicNames := names(cyclic); !reflect.DeepEqual(cyclicNames, test.expectedCyclic) {
+				t.Errorf("expected cyclic components %v, got %v", test.expectedCyclic, cyclicNames)
+			}
+		})
+	}
+}
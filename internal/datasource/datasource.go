@@ -0,0 +1,122 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package datasource
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// note: the artifact URLs returned by ArtifactUrl() have the form <kind>://<namespace>/<name>/<digest>?<key>=<path>&...,
+// where the query is only present if an explicit key-to-path mapping was specified
+const (
+	KindConfigMap = "configmap"
+	KindSecret    = "secret"
+)
+
+var artifactUrlPattern = regexp.MustCompile(`^(configmap|secret)://([^/]+)/([^/]+)/([a-f0-9]+)(?:\?(.*))?$`)
+
+// Return the data of the given config map; keys of data and binaryData are disjoint
+// (this is enforced by the API server), so the result contains all keys of both.
+func ConfigMapData(configMap *corev1.ConfigMap) map[string][]byte {
+	data := make(map[string][]byte, len(configMap.Data)+len(configMap.BinaryData))
+	for key, value := range configMap.Data {
+		data[key] = []byte(value)
+	}
+	for key, value := range configMap.BinaryData {
+		data[key] = value
+	}
+	return data
+}
+
+// Map the given data (keys to content) to files (paths to content). If mapping is empty, every key becomes a file
+// in the root directory. Otherwise, only the keys contained in mapping are considered, and are placed at the specified
+// (relative) paths; in that case, all keys contained in mapping must exist in data.
+func GetFiles(data map[string][]byte, mapping map[string]string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	if len(mapping) == 0 {
+		for key, value := range data {
+			files[key] = value
+		}
+		return files, nil
+	}
+	for key, path := range mapping {
+		value, ok := data[key]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found", key)
+		}
+		if !IsValidFilePath(path) {
+			return nil, fmt.Errorf("invalid file path for key %s: %s", key, path)
+		}
+		if _, ok := files[path]; ok {
+			return nil, fmt.Errorf("duplicate file path: %s", path)
+		}
+		files[path] = value
+	}
+	return files, nil
+}
+
+// Check if the given path is a valid (relative, normalized, non-escaping) file path.
+func IsValidFilePath(path string) bool {
+	return path != "" && path != "." && !filepath.IsAbs(path) && path == filepath.Clean(path) && !strings.Contains(path, "..")
+}
+
+// Calculate the digest of the given files.
+func CalculateDigest(files map[string][]byte) string {
+	// note: json.Marshal() sorts map keys, so the result is deterministic
+	raw, err := json.Marshal(files)
+	if err != nil {
+		panic(err)
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// Build artifact URL for the given object kind (one of KindConfigMap, KindSecret), object key, digest and mapping.
+func ArtifactUrl(kind string, namespace string, name string, digest string, mapping map[string]string) string {
+	artifactUrl := fmt.Sprintf("%s://%s/%s/%s", kind, namespace, name, digest)
+	if len(mapping) > 0 {
+		query := url.Values{}
+		for key, path := range mapping {
+			query.Set(key, path)
+		}
+		artifactUrl += "?" + query.Encode()
+	}
+	return artifactUrl
+}
+
+// Check if the given URL is an artifact URL, as returned by ArtifactUrl().
+func IsArtifactUrl(artifactUrl string) bool {
+	return strings.HasPrefix(artifactUrl, KindConfigMap+"://") || strings.HasPrefix(artifactUrl, KindSecret+"://")
+}
+
+// Parse the given artifact URL (as returned by ArtifactUrl()), and return kind, namespace, name, digest and mapping.
+func ParseArtifactUrl(artifactUrl string) (string, string, string, string, map[string]string, error) {
+	m := artifactUrlPattern.FindStringSubmatch(artifactUrl)
+	if m == nil {
+		return "", "", "", "", nil, fmt.Errorf("invalid artifact URL: %s", artifactUrl)
+	}
+	var mapping map[string]string
+	if m[5] != "" {
+		query, err := url.ParseQuery(m[5])
+		if err != nil {
+			return "", "", "", "", nil, fmt.Errorf("invalid artifact URL: %s", artifactUrl)
+		}
+		mapping = make(map[string]string)
+		for key := range query {
+			mapping[key] = query.Get(key)
+		}
+	}
+	return m[1], m[2], m[3], m[4], mapping, nil
+}
@@ -0,0 +1,422 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package generator
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// note: the format names match the values of the format field of the HttpRepository type
+const (
+	archiveFormatTar     = "tar"
+	archiveFormatTarGzip = "tar+gzip"
+	archiveFormatTarZstd = "tar+zstd"
+	archiveFormatZip     = "zip"
+)
+
+// note: symbolic link targets contained in zip archives are read up to this size
+const maxSymlinkTargetSize = 4096
+
+// limits applied when extracting archives; zero means no limit
+type archiveLimits struct {
+	maxSize     int64
+	maxEntries  int
+	maxFileSize int64
+}
+
+// extractor writes archive entries below a root directory; it ensures that no entry (including link targets)
+// points outside of the root, and enforces the configured limits
+type archiveExtractor struct {
+	root     *os.Root
+	limits   archiveLimits
+	entries  int
+	size     int64
+	symlinks []string
+}
+
+func newArchiveExtractor(targetPath string, limits archiveLimits) (*archiveExtractor, error) {
+	root, err := os.OpenRoot(targetPath)
+	if err != nil {
+		return nil, err
+	}
+	return &archiveExtractor{root: root, limits: limits}, nil
+}
+
+func (x *archiveExtractor) close() error {
+	return x.root.Close()
+}
+
+// check and normalize the given entry name (slash-separated, relative to the archive root); returns the empty string
+// if the entry denotes the archive root itself
+func (x *archiveExtractor) addEntry(name string) (string, error) {
+	x.entries++
+	if x.limits.maxEntries > 0 && x.entries > x.limits.maxEntries {
+		return "", fmt.Errorf("archive exceeds the maximum number of entries (%d)", x.limits.maxEntries)
+	}
+	if path.IsAbs(name) || filepath.IsAbs(name) {
+		return "", fmt.Errorf("archive entry %s has an absolute path", name)
+	}
+	cleanName := path.Clean(name)
+	if cleanName == "." {
+		return "", nil
+	}
+	if !fs.ValidPath(cleanName) {
+		return "", fmt.Errorf("archive entry %s points outside of the archive root", name)
+	}
+	return filepath.FromSlash(cleanName), nil
+}
+
+func (x *archiveExtractor) addDirectory(name string) error {
+	name, err := x.addEntry(name)
+	if err != nil || name == "" {
+		return err
+	}
+	return x.root.MkdirAll(name, 0755)
+}
+
+func (x *archiveExtractor) addFile(name string, mode fs.FileMode, size int64, reader io.Reader) error {
+	entryName := name
+	name, err := x.addEntry(name)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		return fmt.Errorf("archive entry %s is a file, but denotes the archive root", entryName)
+	}
+	if x.limits.maxFileSize > 0 && size > x.limits.maxFileSize {
+		return fmt.Errorf("archive entry %s exceeds the maximum file size (%d bytes > %d bytes)", entryName, size, x.limits.maxFileSize)
+	}
+	x.size += size
+	if x.limits.maxSize > 0 && x.size > x.limits.maxSize {
+		return fmt.Errorf("archive exceeds the maximum total (uncompressed) size of %d bytes", x.limits.maxSize)
+	}
+	if err := x.prepare(name); err != nil {
+		return err
+	}
+	// note: only the executable bits are taken over from the archive; special bits (setuid, setgid, sticky) are dropped
+	perm := fs.FileMode(0644)
+	if mode&0111 != 0 {
+		perm = 0755
+	}
+	file, err := x.root.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	// note: the size declared by the entry might not be trustworthy (e.g. for zip archives), so the content is
+	// read up to one byte beyond the declared size, in order to detect a mismatch
+	n, err := io.Copy(file, io.LimitReader(reader, size+1))
+	if err != nil {
+		return err
+	}
+	if n != size {
+		return fmt.Errorf("archive entry %s does not match its declared size of %d bytes", entryName, size)
+	}
+	return file.Close()
+}
+
+func (x *archiveExtractor) addSymlink(name string, target string) error {
+	entryName := name
+	name, err := x.addEntry(name)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		return fmt.Errorf("archive entry %s is a symbolic link, but denotes the archive root", entryName)
+	}
+	if target == "" {
+		return fmt.Errorf("archive entry %s is a symbolic link with an empty target", entryName)
+	}
+	if path.IsAbs(target) || filepath.IsAbs(target) {
+		return fmt.Errorf("archive entry %s is a symbolic link with an absolute target (%s)", entryName, target)
+	}
+	if resolvedTarget := path.Join(path.Dir(filepath.ToSlash(name)), target); resolvedTarget != "." && !fs.ValidPath(resolvedTarget) {
+		return fmt.Errorf("archive entry %s is a symbolic link pointing outside of the archive root (%s)", entryName, target)
+	}
+	if err := x.prepare(name); err != nil {
+		return err
+	}
+	if err := x.root.Symlink(filepath.FromSlash(target), name); err != nil {
+		return err
+	}
+	x.symlinks = append(x.symlinks, name)
+	return nil
+}
+
+func (x *archiveExtractor) addHardlink(name string, target string) error {
+	entryName := name
+	name, err := x.addEntry(name)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		return fmt.Errorf("archive entry %s is a hard link, but denotes the archive root", entryName)
+	}
+	cleanTarget := path.Clean(target)
+	if path.IsAbs(target) || filepath.IsAbs(target) || !fs.ValidPath(cleanTarget) || cleanTarget == "." {
+		return fmt.Errorf("archive entry %s is a hard link pointing outside of the archive root (%s)", entryName, target)
+	}
+	// note: hard links must refer to a regular file which was extracted before
+	if info, err := x.root.Lstat(filepath.FromSlash(cleanTarget)); err != nil || !info.Mode().IsRegular() {
+		return fmt.Errorf("archive entry %s is a hard link to %s, which is not a regular file contained in the archive", entryName, target)
+	}
+	if err := x.prepare(name); err != nil {
+		return err
+	}
+	return x.root.Link(filepath.FromSlash(cleanTarget), name)
+}
+
+// verify that all symbolic links resolve to a location within the archive root; this has to be done after the extraction
+// is complete, since links may be chained (such that each link on its own seems valid, but the chain escapes the root);
+// dangling links are accepted
+func (x *archiveExtractor) finish() error {
+	for _, name := range x.symlinks {
+		if _, err := x.root.Stat(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("archive entry %s is a symbolic link resolving outside of the archive root", filepath.ToSlash(name))
+		}
+	}
+	return nil
+}
+
+// create parent directories of the given entry, and remove existing files (which might have been written by a previous
+// entry with the same name); note that, due to the use of os.Root, symbolic links are never followed outside of the root
+func (x *archiveExtractor) prepare(name string) error {
+	if dir := filepath.Dir(name); dir != "." {
+		if err := x.root.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	if info, err := x.root.Lstat(name); err == nil {
+		if info.IsDir() {
+			return fmt.Errorf("archive entry %s conflicts with a directory of the same name", filepath.ToSlash(name))
+		}
+		if err := x.root.Remove(name); err != nil {
+			return err
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// extract the given archive stream into targetPath; if format is empty, the format is detected from the leading bytes
+// of the stream, or (if that is not conclusive) from the given content type; createTemp is used to create temporary files
+// needed during the extraction (such as spooled zip archives)
+func extractArchive(reader io.Reader, format string, contentType string, targetPath string, limits archiveLimits, createTemp func() (*os.File, error)) error {
+	bufferedReader := bufio.NewReader(reader)
+	if format == "" {
+		// note: magic bytes are more reliable than the content type (which is often set generically, e.g. to
+		// application/octet-stream), so they take precedence
+		header, err := bufferedReader.Peek(512)
+		if err != nil && err != io.EOF {
+			return err
+		}
+		format = detectArchiveFormat(header)
+		if format == "" {
+			format = archiveFormatFromContentType(contentType)
+		}
+		if format == "" {
+			return fmt.Errorf("unable to detect archive format (supported formats: %s, %s, %s, %s)", archiveFormatTar, archiveFormatTarGzip, archiveFormatTarZstd, archiveFormatZip)
+		}
+	}
+
+	switch format {
+	case archiveFormatTar:
+		return extractTar(bufferedReader, targetPath, limits)
+	case archiveFormatTarGzip:
+		gzipReader, err := gzip.NewReader(bufferedReader)
+		if err != nil {
+			return err
+		}
+		defer gzipReader.Close()
+		return extractTar(gzipReader, targetPath, limits)
+	case archiveFormatTarZstd:
+		zstdReader, err := zstd.NewReader(bufferedReader, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return err
+		}
+		defer zstdReader.Close()
+		return extractTar(zstdReader, targetPath, limits)
+	case archiveFormatZip:
+		return extractZip(bufferedReader, targetPath, limits, createTemp)
+	default:
+		return fmt.Errorf("invalid archive format: %s", format)
+	}
+}
+
+// detect the archive format from the given leading bytes of an archive; returns the empty string if the format is not recognized
+func detectArchiveFormat(header []byte) string {
+	switch {
+	case bytes.HasPrefix(header, []byte{0x1f, 0x8b}):
+		return archiveFormatTarGzip
+	case bytes.HasPrefix(header, []byte{0x28, 0xb5, 0x2f, 0xfd}):
+		return archiveFormatTarZstd
+	case bytes.HasPrefix(header, []byte("PK\x03\x04")) || bytes.HasPrefix(header, []byte("PK\x05\x06")):
+		return archiveFormatZip
+	case len(header) >= 262 && bytes.Equal(header[257:262], []byte("ustar")):
+		return archiveFormatTar
+	default:
+		return ""
+	}
+}
+
+// map the given content type to an archive format; returns the empty string if the content type is not conclusive
+func archiveFormatFromContentType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	switch mediaType {
+	case "application/x-tar":
+		return archiveFormatTar
+	case "application/gzip", "application/x-gzip", "application/x-gtar", "application/x-compressed-tar", "application/x-tgz":
+		return archiveFormatTarGzip
+	case "application/zstd", "application/x-zstd", "application/x-zstd-compressed-tar":
+		return archiveFormatTarZstd
+	case "application/zip", "application/x-zip-compressed":
+		return archiveFormatZip
+	default:
+		return ""
+	}
+}
+
+// extract the given tar stream into targetPath
+func extractTar(reader io.Reader, targetPath string, limits archiveLimits) error {
+	extractor, err := newArchiveExtractor(targetPath, limits)
+	if err != nil {
+		return err
+	}
+	defer extractor.close()
+
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = extractor.addDirectory(header.Name)
+		case tar.TypeReg:
+			err = extractor.addFile(header.Name, fs.FileMode(header.Mode), header.Size, tarReader)
+		case tar.TypeSymlink:
+			err = extractor.addSymlink(header.Name, header.Linkname)
+		case tar.TypeLink:
+			err = extractor.addHardlink(header.Name, header.Linkname)
+		case tar.TypeXGlobalHeader:
+			// note: global pax headers carry metadata only, and are therefore skipped
+			continue
+		default:
+			err = fmt.Errorf("archive entry %s has unsupported type %s", header.Name, describeTarType(header.Typeflag))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return extractor.finish()
+}
+
+func describeTarType(typeflag byte) string {
+	switch typeflag {
+	case tar.TypeChar:
+		return "character device"
+	case tar.TypeBlock:
+		return "block device"
+	case tar.TypeFifo:
+		return "fifo"
+	default:
+		return fmt.Sprintf("%q", typeflag)
+	}
+}
+
+// extract the given zip stream into targetPath; since zip archives cannot be read sequentially,
+// the stream is spooled to a temporary file (created by createTemp) first
+func extractZip(reader io.Reader, targetPath string, limits archiveLimits, createTemp func() (*os.File, error)) error {
+	file, err := createTemp()
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+	// note: the compressed size cannot exceed the uncompressed size by much (apart from overhead), so the
+	// total size limit is applied to the spooled stream as well
+	if limits.maxSize > 0 {
+		reader = io.LimitReader(reader, limits.maxSize+1)
+	}
+	size, err := io.Copy(file, reader)
+	if err != nil {
+		return err
+	}
+	if limits.maxSize > 0 && size > limits.maxSize {
+		return fmt.Errorf("archive exceeds the maximum total size of %d bytes", limits.maxSize)
+	}
+	zipReader, err := zip.NewReader(file, size)
+	if err != nil {
+		return err
+	}
+
+	extractor, err := newArchiveExtractor(targetPath, limits)
+	if err != nil {
+		return err
+	}
+	defer extractor.close()
+
+	for _, zipFile := range zipReader.File {
+		if err := extractZipFile(extractor, zipFile); err != nil {
+			return err
+		}
+	}
+	return extractor.finish()
+}
+
+func extractZipFile(extractor *archiveExtractor, zipFile *zip.File) error {
+	mode := zipFile.Mode()
+	switch {
+	case mode.IsDir():
+		return extractor.addDirectory(zipFile.Name)
+	case mode&fs.ModeSymlink != 0:
+		reader, err := zipFile.Open()
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		target, err := io.ReadAll(io.LimitReader(reader, maxSymlinkTargetSize))
+		if err != nil {
+			return err
+		}
+		return extractor.addSymlink(zipFile.Name, string(target))
+	case mode.IsRegular():
+		if zipFile.UncompressedSize64 > math.MaxInt64 {
+			return fmt.Errorf("archive entry %s has an invalid size", zipFile.Name)
+		}
+		reader, err := zipFile.Open()
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		return extractor.addFile(zipFile.Name, mode, int64(zipFile.UncompressedSize64), reader)
+	default:
+		return fmt.Errorf("archive entry %s has unsupported type %s", zipFile.Name, mode.Type())
+	}
+}
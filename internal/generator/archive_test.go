@@ -0,0 +1,201 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package generator
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sap/component-operator/internal/testutil"
+)
+
+func newTestZip(t *testing.T, files map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	zipWriter := zip.NewWriter(buf)
+	for name, content := range files {
+		// note: stored (uncompressed) entries, such that the archive size grows with the content
+		writer, err := zipWriter.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := writer.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+type testTarEntry struct {
+	header  tar.Header
+	content string
+}
+
+func newTestTar(t *testing.T, entries []testTarEntry) []byte {
+	buf := &bytes.Buffer{}
+	tarWriter := tar.NewWriter(buf)
+	for _, entry := range entries {
+		header := entry.header
+		if header.Typeflag == tar.TypeReg {
+			header.Size = int64(len(entry.content))
+			if header.Mode == 0 {
+				header.Mode = 0644
+			}
+		}
+		if err := tarWriter.WriteHeader(&header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tarWriter.Write([]byte(entry.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func testTarFile(name string, content string) testTarEntry {
+	return testTarEntry{header: tar.Header{Typeflag: tar.TypeReg, Name: name}, content: content}
+}
+
+func testTarSymlink(name string, target string) testTarEntry {
+	return testTarEntry{header: tar.Header{Typeflag: tar.TypeSymlink, Name: name, Linkname: target}}
+}
+
+func testTarHardlink(name string, target string) testTarEntry {
+	return testTarEntry{header: tar.Header{Typeflag: tar.TypeLink, Name: name, Linkname: target}}
+}
+
+func TestExtractTar(t *testing.T) {
+	tests := []struct {
+		name        string
+		entries     []testTarEntry
+		limits      archiveLimits
+		expectedErr string
+	}{
+		{name: "regular files", entries: []testTarEntry{testTarFile("a.yaml", "a"), testTarFile("dir/b.yaml", "b")}},
+		{name: "directory", entries: []testTarEntry{{header: tar.Header{Typeflag: tar.TypeDir, Name: "dir/"}}, testTarFile("dir/b.yaml", "b")}},
+		{name: "root directory", entries: []testTarEntry{{header: tar.Header{Typeflag: tar.TypeDir, Name: "./"}}, testTarFile("./a.yaml", "a")}},
+		{name: "absolute path", entries: []testTarEntry{testTarFile("/etc/a.yaml", "a")}, expectedErr: "has an absolute path"},
+		{name: "path escaping root", entries: []testTarEntry{testTarFile("../a.yaml", "a")}, expectedErr: "points outside of the archive root"},
+		{name: "path escaping root through directory", entries: []testTarEntry{testTarFile("dir/../../a.yaml", "a")}, expectedErr: "points outside of the archive root"},
+		{name: "file denoting root", entries: []testTarEntry{testTarFile(".", "a")}, expectedErr: "denotes the archive root"},
+
+		{name: "symlink", entries: []testTarEntry{testTarFile("a.yaml", "a"), testTarSymlink("dir/b.yaml", "../a.yaml")}},
+		{name: "dangling symlink", entries: []testTarEntry{testTarSymlink("b.yaml", "missing.yaml")}},
+		{name: "symlink to root", entries: []testTarEntry{testTarSymlink("dir/root", "..")}},
+		{name: "symlink with absolute target", entries: []testTarEntry{testTarSymlink("b.yaml", "/etc/passwd")}, expectedErr: "symbolic link with an absolute target"},
+		{name: "symlink escaping root", entries: []testTarEntry{testTarSymlink("dir/b.yaml", "../../a.yaml")}, expectedErr: "symbolic link pointing outside of the archive root"},
+		{name: "symlink with empty target", entries: []testTarEntry{testTarSymlink("b.yaml", "")}, expectedErr: "symbolic link with an empty target"},
+		{name: "chained symlinks escaping root", entries: []testTarEntry{testTarSymlink("dir/up", ".."), testTarSymlink("dir/escape", "up/..")}, expectedErr: "symbolic link resolving outside of the archive root"},
+		{name: "file written through symlink", entries: []testTarEntry{testTarSymlink("dir/up", ".."), testTarSymlink("dir/escape", "up/.."), testTarFile("dir/escape/a.yaml", "a")}, expectedErr: "path escapes"},
+
+		{name: "hardlink", entries: []testTarEntry{testTarFile("a.yaml", "a"), testTarHardlink("dir/b.yaml", "a.yaml")}},
+		{name: "hardlink with absolute target", entries: []testTarEntry{testTarHardlink("b.yaml", "/etc/passwd")}, expectedErr: "hard link pointing outside of the archive root"},
+		{name: "hardlink escaping root", entries: []testTarEntry{testTarHardlink("b.yaml", "../a.yaml")}, expectedErr: "hard link pointing outside of the archive root"},
+		{name: "hardlink to root", entries: []testTarEntry{testTarHardlink("b.yaml", ".")}, expectedErr: "hard link pointing outside of the archive root"},
+		{name: "hardlink to missing file", entries: []testTarEntry{testTarHardlink("b.yaml", "a.yaml")}, expectedErr: "not a regular file contained in the archive"},
+		{name: "hardlink to symlink", entries: []testTarEntry{testTarSymlink("a.yaml", "missing.yaml"), testTarHardlink("b.yaml", "a.yaml")}, expectedErr: "not a regular file contained in the archive"},
+
+		{name: "unsupported type", entries: []testTarEntry{{header: tar.Header{Typeflag: tar.TypeFifo, Name: "fifo"}}}, expectedErr: "unsupported type fifo"},
+
+		{name: "within entry limit", entries: []testTarEntry{testTarFile("a.yaml", "a"), testTarFile("b.yaml", "b")}, limits: archiveLimits{maxEntries: 2}},
+		{name: "exceeding entry limit", entries: []testTarEntry{testTarFile("a.yaml", "a"), testTarFile("b.yaml", "b")}, limits: archiveLimits{maxEntries: 1}, expectedErr: "maximum number of entries (1)"},
+		{name: "within file size limit", entries: []testTarEntry{testTarFile("a.yaml", "aaaa")}, limits: archiveLimits{maxFileSize: 4}},
+		{name: "exceeding file size limit", entries: []testTarEntry{testTarFile("a.yaml", "aaaaa")}, limits: archiveLimits{maxFileSize: 4}, expectedErr: "exceeds the maximum file size (5 bytes > 4 bytes)"},
+		{name: "within total size limit", entries: []testTarEntry{testTarFile("a.yaml", "aaaa"), testTarFile("b.yaml", "bbbb")}, limits: archiveLimits{maxSize: 8}},
+		{name: "exceeding total size limit", entries: []testTarEntry{testTarFile("a.yaml", "aaaa"), testTarFile("b.yaml", "bbbbb")}, limits: archiveLimits{maxSize: 8}, expectedErr: "maximum total (uncompressed) size of 8 bytes"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			archive := newTestTar(t, test.entries)
+			err := extractArchive(bytes.NewReader(archive), archiveFormatTar, "", t.TempDir(), test.limits, nil)
+			testutil.CheckError(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestExtractTarModes(t *testing.T) {
+	archive := newTestTar(t, []testTarEntry{
+		{header: tar.Header{Typeflag: tar.TypeReg, Name: "plain.yaml", Mode: 0600}, content: "a"},
+		{header: tar.Header{Typeflag: tar.TypeReg, Name: "script.sh", Mode: 0700}, content: "b"},
+		{header: tar.Header{Typeflag: tar.TypeReg, Name: "group-executable.sh", Mode: 0610}, content: "c"},
+		{header: tar.Header{Typeflag: tar.TypeReg, Name: "setuid.sh", Mode: 04755}, content: "d"},
+		{header: tar.Header{Typeflag: tar.TypeReg, Name: "sticky.yaml", Mode: 01666}, content: "e"},
+	})
+	targetPath := t.TempDir()
+	if err := extractArchive(bytes.NewReader(archive), archiveFormatTar, "", targetPath, archiveLimits{}, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedExecutable := map[string]bool{
+		"plain.yaml":          false,
+		"script.sh":           true,
+		"group-executable.sh": true,
+		"setuid.sh":           true,
+		"sticky.yaml":         false,
+	}
+	for name, executable := range expectedExecutable {
+		info, err := os.Lstat(filepath.Join(targetPath, name))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		// note: the exact permissions depend on the umask of the test process, so only the relevant bits are checked
+		mode := info.Mode()
+		if !mode.IsRegular() || mode&(fs.ModeSetuid|fs.ModeSetgid|fs.ModeSticky) != 0 {
+			t.Errorf("file %s: expected regular file without special bits, got mode %s", name, mode)
+		}
+		if mode.Perm()&^0755 != 0 {
+			t.Errorf("file %s: expected no permissions beyond 0755, got mode %s", name, mode)
+		}
+		if (mode&0100 != 0) != executable {
+			t.Errorf("file %s: expected executable: %t, got mode %s", name, executable, mode)
+		}
+	}
+}
+
+func TestExtractZip(t *testing.T) {
+	tempDir := t.TempDir()
+	createTemp := func() (*os.File, error) {
+		return os.CreateTemp(tempDir, "spool-")
+	}
+	archive := newTestZip(t, map[string]string{"manifests/configmap.yaml": strings.Repeat("x", 1024)})
+
+	tests := []struct {
+		name        string
+		limits      archiveLimits
+		expectedErr string
+	}{
+		{name: "no limit"},
+		{name: "within limit", limits: archiveLimits{maxSize: int64(len(archive))}},
+		{name: "spooled archive exceeds limit", limits: archiveLimits{maxSize: 512}, expectedErr: "exceeds the maximum total size of 512 bytes"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			targetPath := t.TempDir()
+			err := extractArchive(bytes.NewReader(archive), "", "", targetPath, test.limits, createTemp)
+			if !testutil.CheckError(t, err, test.expectedErr) {
+				if _, err := os.Stat(filepath.Join(targetPath, "manifests", "configmap.yaml")); err != nil {
+					t.Errorf("expected extracted file: %s", err)
+				}
+			}
+			if entries, err := os.ReadDir(tempDir); err != nil || len(entries) > 0 {
+				t.Errorf("expected spool file to be removed (entries: %v, err: %v)", entries, err)
+			}
+		})
+	}
+}
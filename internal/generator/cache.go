@@ -0,0 +1,119 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package generator
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/sap/component-operator-runtime/pkg/manifests"
+)
+
+// in-memory cache of generators; entries not accessed within the configured ttl are expired;
+// if the number of entries or their (approximate) total size exceed the configured maximum,
+// the least recently used entries are evicted
+type generatorCache struct {
+	ttl        time.Duration
+	maxEntries int
+	maxSize    int64
+	mutex      sync.Mutex
+	items      map[string]*list.Element
+	// least recently used items are at the back of the list
+	lru  *list.List
+	size int64
+}
+
+type cacheItem struct {
+	id         string
+	generator  manifests.Generator
+	size       int64
+	validUntil time.Time
+}
+
+func newGeneratorCache(ttl time.Duration, maxEntries int, maxSize int64) *generatorCache {
+	return &generatorCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		maxSize:    maxSize,
+		items:      make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+func (c *generatorCache) get(id string) (manifests.Generator, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// note: hits and misses are not counted here, but by the caller (which also checks whether the generator is up to date)
+	element, ok := c.items[id]
+	if !ok {
+		return nil, false
+	}
+	item := element.Value.(*cacheItem)
+	item.validUntil = time.Now().Add(c.ttl)
+	c.lru.MoveToFront(element)
+	return item.generator, true
+}
+
+func (c *generatorCache) add(id string, generator manifests.Generator, size int64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, ok := c.items[id]; ok {
+		c.remove(element)
+	}
+	item := &cacheItem{
+		id:         id,
+		generator:  generator,
+		size:       size,
+		validUntil: time.Now().Add(c.ttl),
+	}
+	c.items[id] = c.lru.PushFront(item)
+	c.size += size
+
+	// note: the item just added is never evicted, even if it alone exceeds the size limit
+	for c.maxEntries > 0 && c.lru.Len() > c.maxEntries && c.lru.Len() > 1 {
+		c.remove(c.lru.Back())
+		cacheEvictions.WithLabelValues(evictionReasonMaxEntries).Inc()
+	}
+	for c.maxSize > 0 && c.size > c.maxSize && c.lru.Len() > 1 {
+		c.remove(c.lru.Back())
+		cacheEvictions.WithLabelValues(evictionReasonMaxSize).Inc()
+	}
+	c.updateMetrics()
+}
+
+func (c *generatorCache) expire() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	for element := c.lru.Back(); element != nil; {
+		item := element.Value.(*cacheItem)
+		prev := element.Prev()
+		if item.validUntil.Before(now) {
+			c.remove(element)
+			cacheEvictions.WithLabelValues(evictionReasonExpired).Inc()
+		}
+		element = prev
+	}
+	c.updateMetrics()
+}
+
+// note: must be called with c.mutex held
+func (c *generatorCache) remove(element *list.Element) {
+	item := element.Value.(*cacheItem)
+	c.lru.Remove(element)
+	delete(c.items, item.id)
+	c.size -= item.size
+}
+
+// note: must be called with c.mutex held
+func (c *generatorCache) updateMetrics() {
+	cacheEntries.Set(float64(c.lru.Len()))
+	cacheSize.Set(float64(c.size))
+}
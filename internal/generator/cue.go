@@ -0,0 +1,127 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package generator
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/build"
+	"cuelang.org/go/cue/cuecontext"
+	cueerrors "cuelang.org/go/cue/errors"
+	"cuelang.org/go/cue/load"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/sap/component-operator-runtime/pkg/manifests"
+	componentoperatorruntimetypes "github.com/sap/component-operator-runtime/pkg/types"
+)
+
+// CueGenerator evaluates the CUE package contained in a given directory; the values are unified with the field
+// at valuesPath, and the objects are taken from the field at objectsPath. The latter may contain a single object,
+// a list, or a struct whose fields contain objects (recursively). CUE modules (cue.mod) are supported, as long as
+// they reside within the artifact; dependencies are not fetched from registries.
+type CueGenerator struct {
+	// note: cue values and contexts are not safe for concurrent use, so evaluations are serialized
+	mutex       sync.Mutex
+	instance    *build.Instance
+	valuesPath  cue.Path
+	objectsPath cue.Path
+}
+
+var _ manifests.Generator = &CueGenerator{}
+
+// Create a new CueGenerator for the given directory (relative to the root of fsys). The package is loaded
+// (and parsed) upon creation; fsys is not accessed afterwards.
+func NewCueGenerator(fsys fs.FS, dir string, valuesPath string, objectsPath string) (*CueGenerator, error) {
+	if valuesPath == "" {
+		valuesPath = "values"
+	}
+	if objectsPath == "" {
+		objectsPath = "objects"
+	}
+	g := &CueGenerator{
+		valuesPath:  cue.ParsePath(valuesPath),
+		objectsPath: cue.ParsePath(objectsPath),
+	}
+	if err := g.valuesPath.Err(); err != nil {
+		return nil, fmt.Errorf("invalid CUE values path %s: %w", valuesPath, err)
+	}
+	if err := g.objectsPath.Err(); err != nil {
+		return nil, fmt.Errorf("invalid CUE objects path %s: %w", objectsPath, err)
+	}
+
+	instances := load.Instances([]string{"."}, &load.Config{
+		FS:  fsys,
+		Dir: "/" + path.Clean(dir),
+		// note: dependencies must not be fetched from (arbitrary) registries
+		Env: []string{"CUE_REGISTRY=none"},
+	})
+	if len(instances) != 1 {
+		return nil, fmt.Errorf("expected exactly one CUE package in %s, found %d", dir, len(instances))
+	}
+	if err := instances[0].Err; err != nil {
+		return nil, fmt.Errorf("error loading CUE package in %s: %s", dir, formatCueError(err))
+	}
+	g.instance = instances[0]
+
+	// note: build the instance once, in order to report errors early
+	if err := cuecontext.New().BuildInstance(g.instance).Err(); err != nil {
+		return nil, fmt.Errorf("error building CUE package in %s: %s", dir, formatCueError(err))
+	}
+
+	return g, nil
+}
+
+// Implement the manifests.Generator interface.
+func (g *CueGenerator) Generate(ctx context.Context, namespace string, name string, parameters componentoperatorruntimetypes.Unstructurable) ([]client.Object, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	value := cuecontext.New().BuildInstance(g.instance)
+	if err := value.Err(); err != nil {
+		return nil, fmt.Errorf("error building CUE package: %s", formatCueError(err))
+	}
+
+	value = value.FillPath(g.valuesPath, parameters.ToUnstructured())
+	// note: schema violations are terminal errors; retrying will not help unless the component (or its source) is changed,
+	// which triggers a reconciliation anyway
+	if err := value.LookupPath(g.valuesPath).Validate(cue.Concrete(true)); err != nil {
+		return nil, reconcile.TerminalError(fmt.Errorf("values do not conform to the CUE schema: %s", formatCueError(err)))
+	}
+
+	objectsValue := value.LookupPath(g.objectsPath)
+	if !objectsValue.Exists() {
+		return nil, fmt.Errorf("CUE package does not define %s", g.objectsPath)
+	}
+	if err := objectsValue.Validate(cue.Concrete(true)); err != nil {
+		return nil, fmt.Errorf("error evaluating %s: %s", g.objectsPath, formatCueError(err))
+	}
+	var result any
+	if err := objectsValue.Decode(&result); err != nil {
+		return nil, fmt.Errorf("error decoding %s: %s", g.objectsPath, formatCueError(err))
+	}
+
+	var objects []client.Object
+	if err := collectObjects(result, &objects); err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+func formatCueError(err error) string {
+	var messages []string
+	for _, e := range cueerrors.Errors(err) {
+		messages = append(messages, strings.TrimSuffix(e.Error(), ":"))
+	}
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,72 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package generator
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"regexp"
+
+	"github.com/zeebo/blake3"
+)
+
+// note: the supported algorithms are the ones used by flux for artifact digests
+var verifiableDigestPattern = regexp.MustCompile(`^(sha256|sha384|sha512|blake3):([a-f0-9]+)$`)
+
+// reader which hashes the data read from the underlying reader, and returns an error instead of io.EOF
+// if the digest of the data does not match the expected digest
+type verifyingReader struct {
+	reader    io.Reader
+	hash      hash.Hash
+	algorithm string
+	expected  string
+}
+
+// wrap the given reader such that the read data is verified against the given digest (in the format <algorithm>:<hex>);
+// if the digest is not verifiable (unknown format or algorithm), the reader is returned unchanged
+func newVerifyingReader(reader io.Reader, digest string) (io.Reader, error) {
+	m := verifiableDigestPattern.FindStringSubmatch(digest)
+	if m == nil {
+		return reader, nil
+	}
+	var h hash.Hash
+	switch m[1] {
+	case "sha256":
+		h = sha256.New()
+	case "sha384":
+		h = sha512.New384()
+	case "sha512":
+		h = sha512.New()
+	case "blake3":
+		h = blake3.New()
+	default:
+		panic("this cannot happen")
+	}
+	if len(m[2]) != 2*h.Size() {
+		return nil, fmt.Errorf("invalid digest %s: %s requires %d hex digits", digest, m[1], 2*h.Size())
+	}
+	return &verifyingReader{
+		reader:    reader,
+		hash:      h,
+		algorithm: m[1],
+		expected:  m[2],
+	}, nil
+}
+
+func (r *verifyingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.hash.Write(p[:n])
+	if err == io.EOF {
+		if actual := hex.EncodeToString(r.hash.Sum(nil)); actual != r.expected {
+			return n, fmt.Errorf("digest mismatch: downloaded artifact has digest %s:%s, expected %s:%s (the artifact might have been tampered with or truncated)", r.algorithm, actual, r.algorithm, r.expected)
+		}
+	}
+	return n, err
+}
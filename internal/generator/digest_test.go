@@ -0,0 +1,94 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package generator
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	"github.com/zeebo/blake3"
+
+	"github.com/sap/component-operator/internal/testutil"
+)
+
+func TestVerifyingReader(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789abcdef"), 4096)
+	other := bytes.Repeat([]byte("fedcba9876543210"), 4096)
+
+	digests := map[string]func([]byte) string{
+		"sha256": func(b []byte) string { s := sha256.Sum256(b); return hex.EncodeToString(s[:]) },
+		"sha384": func(b []byte) string { s := sha512.Sum384(b); return hex.EncodeToString(s[:]) },
+		"sha512": func(b []byte) string { s := sha512.Sum512(b); return hex.EncodeToString(s[:]) },
+		"blake3": func(b []byte) string { s := blake3.Sum256(b); return hex.EncodeToString(s[:]) },
+	}
+
+	for _, algorithm := range []string{"sha256", "sha384", "sha512", "blake3"} {
+		digest := algorithm + ":" + digests[algorithm](data)
+
+		tests := []struct {
+			name        string
+			content     []byte
+			digest      string
+			expectedErr string
+		}{
+			{name: "match", content: data, digest: digest},
+			{name: "mismatch", content: other, digest: digest, expectedErr: "digest mismatch"},
+			{name: "truncated", content: data[:len(data)-1], digest: digest, expectedErr: "digest mismatch"},
+			{name: "empty", content: nil, digest: digest, expectedErr: "digest mismatch"},
+		}
+
+		for _, test := range tests {
+			t.Run(algorithm+"/"+test.name, func(t *testing.T) {
+				reader, err := newVerifyingReader(bytes.NewReader(test.content), test.digest)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				content, err := io.ReadAll(reader)
+				if testutil.CheckError(t, err, test.expectedErr) {
+					return
+				}
+				if !bytes.Equal(content, test.content) {
+					t.Errorf("read content differs from the underlying content")
+				}
+			})
+		}
+	}
+}
+
+func TestNewVerifyingReader(t *testing.T) {
+	tests := []struct {
+		name        string
+		digest      string
+		verifying   bool
+		expectedErr string
+	}{
+		{name: "etag", digest: `"5d41402abc4b2a76b9719d911017c592"`},
+		{name: "unknown algorithm", digest: "md5:5d41402abc4b2a76b9719d911017c592"},
+		{name: "empty", digest: ""},
+		{name: "sha256", digest: "sha256:" + hex.EncodeToString(make([]byte, 32)), verifying: true},
+		{name: "blake3", digest: "blake3:" + hex.EncodeToString(make([]byte, 32)), verifying: true},
+		{name: "sha256 too short", digest: "sha256:" + hex.EncodeToString(make([]byte, 31)), expectedErr: "requires 64 hex digits"},
+		{name: "sha384 with sha256 length", digest: "sha384:" + hex.EncodeToString(make([]byte, 32)), expectedErr: "requires 96 hex digits"},
+		{name: "sha512 too long", digest: "sha512:" + hex.EncodeToString(make([]byte, 65)), expectedErr: "requires 128 hex digits"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			underlying := bytes.NewReader(nil)
+			reader, err := newVerifyingReader(underlying, test.digest)
+			if testutil.CheckError(t, err, test.expectedErr) {
+				return
+			}
+			if _, ok := reader.(*verifyingReader); ok != test.verifying {
+				t.Errorf("expected verifying reader: %t, got %T", test.verifying, reader)
+			}
+		})
+	}
+}
@@ -6,9 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package generator
 
 import (
-	"archive/tar"
 	"bytes"
-	"compress/gzip"
 	"context"
 	"errors"
 	"fmt"
@@ -19,134 +17,326 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
-	"sync"
 	"time"
 
+	"golang.org/x/sync/singleflight"
+
+	corev1 "k8s.io/api/core/v1"
 	apitypes "k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/sap/component-operator-runtime/pkg/manifests"
-	"github.com/sap/component-operator-runtime/pkg/manifests/helm"
 	"github.com/sap/component-operator-runtime/pkg/manifests/kustomize"
 
 	operatorv1alpha1 "github.com/sap/component-operator/api/v1alpha1"
+	artifactcache "github.com/sap/component-operator/internal/cache/artifact"
+	"github.com/sap/component-operator/internal/datasource"
 	"github.com/sap/component-operator/internal/decrypt"
+	gitrepositoryutil "github.com/sap/component-operator/internal/gitrepository/util"
+	httprepositoryutil "github.com/sap/component-operator/internal/httprepository/util"
+	ocirepositoryutil "github.com/sap/component-operator/internal/ocirepository/util"
 )
 
-type Item struct {
-	Generator  manifests.Generator
-	ValidUntil time.Time
+// settings determining which generator is created (besides the source itself); note that the struct is part
+// of the generator id, so it must be serializable
+type generatorConfig struct {
+	Type    operatorv1alpha1.GeneratorType
+	Helm    *operatorv1alpha1.HelmGeneratorOptions
+	Plain   *operatorv1alpha1.PlainGeneratorOptions
+	Jsonnet *operatorv1alpha1.JsonnetGeneratorOptions
+	Cue     *operatorv1alpha1.CueGeneratorOptions
 }
 
-// TODO: make configurable
-const validity = 60 * time.Minute
-
 type Factory struct {
-	client client.Client
-	items  map[string]*Item
-	mutex  sync.Mutex
+	client        client.Client
+	artifactCache *artifactcache.Cache
+	archiveLimits archiveLimits
+	cache         *generatorCache
+	group         singleflight.Group
 }
 
-func newFactory(clnt client.Client) *Factory {
+func newFactory(clnt client.Client, options Options) *Factory {
 	factory := &Factory{
-		client: clnt,
-		items:  make(map[string]*Item),
+		client:        clnt,
+		artifactCache: options.ArtifactCache,
+		archiveLimits: archiveLimits{
+			maxSize:     options.ArchiveMaxSize,
+			maxEntries:  options.ArchiveMaxEntries,
+			maxFileSize: options.ArchiveMaxFileSize,
+		},
+		cache: newGeneratorCache(options.CacheTTL, options.CacheMaxEntries, options.CacheMaxSize),
 	}
 
 	go func() {
-		ticker := time.NewTicker(10 * time.Second)
+		ticker := time.NewTicker(min(10*time.Second, options.CacheTTL))
 		for {
 			<-ticker.C
-			now := time.Now()
-			factory.mutex.Lock()
-			for id, item := range factory.items {
-				if item.ValidUntil.Before(now) {
-					delete(factory.items, id)
-				}
-			}
-			factory.mutex.Unlock()
+			factory.cache.expire()
 		}
 	}()
 
 	return factory
 }
 
-func (f *Factory) GetGenerator(url string, path string, digest string, decryptionProvider string, decryptionKeys map[string][]byte) (manifests.Generator, error) {
-	f.mutex.Lock()
-	defer f.mutex.Unlock()
+func (f *Factory) GetGenerator(url string, path string, digest string, format string, config generatorConfig, sourceCredentials map[string][]byte, decryptionProvider string, decryptionKeys map[string][]byte) (manifests.Generator, error) {
+	id := generatorId(url, path, digest, format, config, decryptionProvider, decryptionKeys)
 
-	// note: url is actually not needed in the generator id, digest and path is enough to identify the content
-	id := url + "\n" + digest + "\n" + path + "\n" + decryptionProvider + "\n" + calculateDigest(decryptionKeys)
+	// note: generators using external sources (such as helm charts with dependencies to blueprints) are recreated
+	// if one of these sources changed; such outdated generators are counted as cache misses
+	if generator, ok := f.cache.get(id); ok && f.isUpToDate(generator) {
+		cacheHits.Inc()
+		return generator, nil
+	}
+	cacheMisses.Inc()
 
-	if item, ok := f.items[id]; ok {
-		item.ValidUntil = time.Now().Add(validity)
-		return item.Generator, nil
-	} else {
-		tmpdir, err := os.MkdirTemp("", "component-operator-")
+	// note: concurrent requests for the same generator id are coalesced, such that the generator is only created once;
+	// requests for different ids proceed in parallel
+	generator, err, _ := f.group.Do(id, func() (any, error) {
+		// note: the generator might have been created by a concurrent request in the meantime
+		if generator, ok := f.cache.get(id); ok && f.isUpToDate(generator) {
+			return generator, nil
+		}
+		generator, size, err := f.newGenerator(url, path, digest, format, config, sourceCredentials, decryptionProvider, decryptionKeys)
 		if err != nil {
 			return nil, err
 		}
-		defer func() {
-			os.RemoveAll(tmpdir)
-		}()
-		var decryptor manifests.Decryptor
-		if len(decryptionKeys) > 0 {
-			switch decryptionProvider {
-			case "sops", "":
-				sopsDecryptor, err := decrypt.NewSopsDecryptor(decryptionKeys)
-				if err != nil {
-					return nil, err
-				}
-				defer sopsDecryptor.Cleanup()
-				decryptor = sopsDecryptor
-			default:
-				return nil, fmt.Errorf("invalid decryption provider: %s", decryptionProvider)
+		f.cache.add(id, generator, size)
+		return generator, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return generator.(manifests.Generator), nil
+}
+
+func generatorId(url string, path string, digest string, format string, config generatorConfig, decryptionProvider string, decryptionKeys map[string][]byte) string {
+	// note: url is actually not needed in the generator id, digest and path is enough to identify the content
+	return url + "\n" + digest + "\n" + format + "\n" + path + "\n" + calculateDigest(config) + "\n" + decryptionProvider + "\n" + calculateDigest(decryptionKeys)
+}
+
+func (f *Factory) newGenerator(url string, path string, digest string, format string, config generatorConfig, sourceCredentials map[string][]byte, decryptionProvider string, decryptionKeys map[string][]byte) (manifests.Generator, int64, error) {
+	var decryptor manifests.Decryptor
+	if len(decryptionKeys) > 0 {
+		switch decryptionProvider {
+		case "sops", "":
+			sopsDecryptor, err := decrypt.NewSopsDecryptor(decryptionKeys)
+			if err != nil {
+				return nil, 0, err
 			}
+			defer sopsDecryptor.Cleanup()
+			decryptor = sopsDecryptor
+		default:
+			return nil, 0, fmt.Errorf("invalid decryption provider: %s", decryptionProvider)
 		}
-		if strings.HasPrefix(url, "blueprint://") {
-			if err := f.downloadBlueprint(url, tmpdir); err != nil {
-				return nil, err
-			}
+	}
+	dir, release, err := f.acquireArtifact(url, digest, format, sourceCredentials)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer release()
+	fullPath := filepath.Join(dir, path)
+	if info, err := os.Stat(fullPath); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, 0, fmt.Errorf("no such file or directory: %s", path)
 		} else {
-			if err := f.downloadArchive(url, tmpdir); err != nil {
-				return nil, err
-			}
+			return nil, 0, err
+		}
+	} else if !info.IsDir() {
+		return nil, 0, fmt.Errorf("not a directory: %s", path)
+	}
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer root.Close()
+
+	generatorType := config.Type
+	if generatorType == "" || generatorType == operatorv1alpha1.GeneratorAuto {
+		if _, err := root.Stat(filepath.Join(path, "Chart.yaml")); err == nil {
+			generatorType = operatorv1alpha1.GeneratorHelm
+		} else if errors.Is(err, fs.ErrNotExist) {
+			generatorType = operatorv1alpha1.GeneratorKustomize
+		} else {
+			return nil, 0, err
 		}
-		fullPath := filepath.Join(tmpdir, path)
-		if info, err := os.Stat(fullPath); err != nil {
+	}
+
+	var generator manifests.Generator
+	switch generatorType {
+	case operatorv1alpha1.GeneratorHelm:
+		if _, err := root.Stat(filepath.Join(path, "Chart.yaml")); err != nil {
 			if errors.Is(err, fs.ErrNotExist) {
-				return nil, fmt.Errorf("no such file or directory: %s", path)
-			} else {
-				return nil, err
+				return nil, 0, fmt.Errorf("no Chart.yaml found in %s (required by helm generator)", path)
 			}
-		} else if !info.IsDir() {
-			return nil, fmt.Errorf("not a directory: %s", path)
+			return nil, 0, err
 		}
-		root, err := os.OpenRoot(tmpdir)
+		chartPath := filepath.ToSlash(filepath.Clean(path))
+		hasDependencies, err := helmChartHasDependencies(root.FS(), chartPath)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
-		defer root.Close()
-
-		var generator manifests.Generator
-		if _, err = root.Stat(filepath.Join(path, "Chart.yaml")); err == nil {
-			if err := decryptDirectory(root, path, decryptor); err != nil {
-				return nil, err
-			}
-			generator, err = helm.NewHelmGenerator(root.FS(), path, nil)
+		var externalSources []externalSource
+		if decryptor != nil || hasDependencies {
+			// note: decryption and vendoring of dependencies happen in place, so the chart has to be copied first
+			// (since the cached tree must not be modified)
+			tmpdir, err := os.MkdirTemp("", "component-operator-")
 			if err != nil {
-				return nil, err
+				return nil, 0, err
 			}
-		} else if errors.Is(err, fs.ErrNotExist) {
-			generator, err = kustomize.NewKustomizeGenerator(root.FS(), path, nil, kustomize.KustomizeGeneratorOptions{Decryptor: decryptor})
+			defer func() {
+				os.RemoveAll(tmpdir)
+			}()
+			if err := copyDirectory(root.FS(), chartPath, filepath.Join(tmpdir, path)); err != nil {
+				return nil, 0, err
+			}
+			sourceRoot := root
+			root, err = os.OpenRoot(tmpdir)
 			if err != nil {
-				return nil, err
+				return nil, 0, err
 			}
+			defer root.Close()
+			if hasDependencies {
+				if err := f.vendorHelmDependencies(root, chartPath, helmChartSource{fsys: sourceRoot.FS(), dir: chartPath}, 0, &externalSources); err != nil {
+					return nil, 0, err
+				}
+			}
+			if err := decryptDirectory(root, path, decryptor); err != nil {
+				return nil, 0, err
+			}
+		}
+		helmGenerator, err := NewHelmGenerator(root.FS(), chartPath, config.Helm)
+		if err != nil {
+			return nil, 0, err
+		}
+		helmGenerator.externalSources = externalSources
+		generator = helmGenerator
+	case operatorv1alpha1.GeneratorKustomize:
+		generator, err = kustomize.NewKustomizeGenerator(root.FS(), path, nil, kustomize.KustomizeGeneratorOptions{Decryptor: decryptor})
+		if err != nil {
+			return nil, 0, err
+		}
+	case operatorv1alpha1.GeneratorPlain:
+		var templating bool
+		if config.Plain != nil {
+			templating = config.Plain.Templating
+		}
+		generator, err = NewPlainGenerator(root.FS(), filepath.ToSlash(path), templating, decryptor)
+		if err != nil {
+			return nil, 0, err
+		}
+	case operatorv1alpha1.GeneratorJsonnet:
+		mainFile := "main.jsonnet"
+		var valuesMode, valuesName string
+		if config.Jsonnet != nil {
+			if config.Jsonnet.MainFile != "" {
+				mainFile = config.Jsonnet.MainFile
+			}
+			valuesMode = config.Jsonnet.ValuesMode
+			valuesName = config.Jsonnet.ValuesName
+		}
+		generator, err = NewJsonnetGenerator(root.FS(), filepath.ToSlash(filepath.Join(path, mainFile)), valuesMode, valuesName, decryptor)
+		if err != nil {
+			return nil, 0, err
+		}
+	case operatorv1alpha1.GeneratorCue:
+		if decryptor != nil {
+			return nil, 0, fmt.Errorf("decryption is not supported by the cue generator")
+		}
+		var valuesPath, objectsPath string
+		if config.Cue != nil {
+			valuesPath = config.Cue.ValuesPath
+			objectsPath = config.Cue.ObjectsPath
+		}
+		generator, err = NewCueGenerator(root.FS(), filepath.ToSlash(path), valuesPath, objectsPath)
+		if err != nil {
+			return nil, 0, err
+		}
+	default:
+		return nil, 0, fmt.Errorf("invalid generator: %s", generatorType)
+	}
+	// note: the size of the source files is used as an approximation of the memory consumed by the generator
+	size, err := directorySize(fullPath)
+	if err != nil {
+		return nil, 0, err
+	}
+	return generator, size, nil
+}
+
+// make the content of the given artifact available in a local directory; the returned function must be called
+// once the directory is no longer needed
+func (f *Factory) acquireArtifact(url string, digest string, format string, credentials map[string][]byte) (string, func(), error) {
+	if strings.HasPrefix(url, "blueprint://") || datasource.IsArtifactUrl(url) {
+		// note: these sources are read from the cluster, so there is no benefit in caching them on disk
+		tmpdir, err := os.MkdirTemp("", "component-operator-")
+		if err != nil {
+			return "", nil, err
+		}
+		release := func() {
+			os.RemoveAll(tmpdir)
+		}
+		if strings.HasPrefix(url, "blueprint://") {
+			err = f.downloadBlueprint(url, tmpdir)
 		} else {
-			return nil, err
+			err = f.downloadDataSource(url, tmpdir)
+		}
+		if err != nil {
+			release()
+			return "", nil, err
+		}
+		return tmpdir, release, nil
+	}
+	key, verified, err := artifactCacheKey(url, digest)
+	if err != nil {
+		return "", nil, err
+	}
+	fill := func(dir string) error {
+		if gitrepositoryutil.IsArtifactUrl(url) {
+			return f.checkoutGitRepository(url, credentials, dir)
+		} else {
+			return f.downloadArchive(url, digest, format, credentials, dir)
 		}
-		f.items[id] = &Item{Generator: generator, ValidUntil: time.Now().Add(validity)}
-		return generator, nil
+	}
+	if !verified {
+		// note: artifacts which cannot be verified (such as http artifacts only identified by an ETag) are not stored
+		// in the artifact cache; they are downloaded again whenever a generator is created
+		tmpdir, err := f.artifactCache.MkdirTemp()
+		if err != nil {
+			return "", nil, err
+		}
+		release := func() {
+			os.RemoveAll(tmpdir)
+		}
+		if err := fill(tmpdir); err != nil {
+			release()
+			return "", nil, err
+		}
+		return tmpdir, release, nil
+	}
+	// note: the cache entry only depends on the verified digest (and not on path or decryption settings), such that generators
+	// for different paths, and even sources with different (e.g. short-lived or rotating) URLs for the same content, share one extracted tree
+	return f.artifactCache.Acquire(key, fill)
+}
+
+// return the key of the artifact cache entry for the given artifact, and whether the content of the artifact will be verified
+// against the key when downloaded (only such artifacts may be stored in the artifact cache)
+func artifactCacheKey(url string, digest string) (string, bool, error) {
+	switch {
+	case gitrepositoryutil.IsArtifactUrl(url):
+		// note: the digest of git artifacts is the commit hash (sha1:<commit>); the checkout fails if the fetched commit differs;
+		// since an explicitly requested commit is not resolved against the remote repository before the checkout, the repository
+		// URL is part of the key (otherwise, the content of a cached commit could be obtained by just knowing its hash)
+		return calculateDigest(url, digest), true, nil
+	case ocirepositoryutil.IsArtifactUrl(url):
+		// note: the digest of OCI artifacts is the manifest digest; the downloaded layer is verified against the layer digest
+		layerDigest, err := ocirepositoryutil.GetLayerDigest(url)
+		if err != nil {
+			return "", false, err
+		}
+		return calculateDigest(layerDigest), true, nil
+	case verifiableDigestPattern.MatchString(digest):
+		return calculateDigest(digest), true, nil
+	default:
+		return "", false, nil
 	}
 }
 
@@ -161,86 +351,167 @@ func (f *Factory) downloadBlueprint(url string, targetPath string) error {
 			return err
 		}
 
-		for path, content := range blueprintVersion.Spec.Files {
-			if path != filepath.Clean(path) || strings.Contains(path, "..") {
-				return fmt.Errorf("invalid file path in blueprint: %s", path)
-			}
-			if err := os.MkdirAll(filepath.Join(targetPath, filepath.Dir(path)), 0755); err != nil {
-				return err
-			}
-			if err := os.WriteFile(filepath.Join(targetPath, path), []byte(content), 0644); err != nil {
-				return err
-			}
-		}
-
-		return nil
+		return writeBlueprintFiles(blueprintVersion.Spec.Files, targetPath)
 	} else {
 		return fmt.Errorf("invalid blueprint URL: %s", url)
 	}
 }
 
-func (f *Factory) downloadArchive(url string, targetPath string) error {
-	// TODO: use a local or even global file cache
-	resp, err := http.Get(url)
+func writeBlueprintFiles(files map[string]string, targetPath string) error {
+	for path, content := range files {
+		if path != filepath.Clean(path) || strings.Contains(path, "..") {
+			return fmt.Errorf("invalid file path in blueprint: %s", path)
+		}
+		if err := os.MkdirAll(filepath.Join(targetPath, filepath.Dir(path)), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(targetPath, path), []byte(content), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Factory) downloadDataSource(url string, targetPath string) error {
+	kind, namespace, name, digest, mapping, err := datasource.ParseArtifactUrl(url)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("error downloading %s: %s", url, resp.Status)
+
+	var data map[string][]byte
+	switch kind {
+	case datasource.KindConfigMap:
+		configMap := corev1.ConfigMap{}
+		if err := f.client.Get(context.TODO(), apitypes.NamespacedName{Namespace: namespace, Name: name}, &configMap); err != nil {
+			return err
+		}
+		data = datasource.ConfigMapData(&configMap)
+	case datasource.KindSecret:
+		secret := corev1.Secret{}
+		if err := f.client.Get(context.TODO(), apitypes.NamespacedName{Namespace: namespace, Name: name}, &secret); err != nil {
+			return err
+		}
+		data = secret.Data
+	default:
+		panic("this cannot happen")
 	}
 
-	gzipReader, err := gzip.NewReader(resp.Body)
+	files, err := datasource.GetFiles(data, mapping)
 	if err != nil {
 		return err
 	}
-	defer gzipReader.Close()
+	// note: the object might have changed since the artifact was resolved; in that case, the component will be
+	// reconciled anyway (triggered by the according watch), so it is safe to just fail here
+	if actualDigest := datasource.CalculateDigest(files); actualDigest != digest {
+		return fmt.Errorf("digest of %s %s/%s (%s) does not match expected digest (%s)", kind, namespace, name, actualDigest, digest)
+	}
 
-	tarReader := tar.NewReader(gzipReader)
-	for {
-		header, err := tarReader.Next()
-		if err == io.EOF {
-			break
+	for path, content := range files {
+		if !datasource.IsValidFilePath(path) {
+			return fmt.Errorf("invalid file path in %s: %s", kind, path)
 		}
-		if err != nil {
+		if err := os.MkdirAll(filepath.Join(targetPath, filepath.Dir(path)), 0755); err != nil {
 			return err
 		}
-		if header.Name == "." {
-			continue
+		if err := os.WriteFile(filepath.Join(targetPath, path), content, 0644); err != nil {
+			return err
 		}
-		if filepath.IsAbs(header.Name) {
-			return fmt.Errorf("archive must not contain entries with absolute paths (%s)", header.Name)
+	}
+
+	return nil
+}
+
+func (f *Factory) checkoutGitRepository(url string, credentials map[string][]byte, targetPath string) error {
+	var gitCredentials *gitrepositoryutil.Credentials
+	if len(credentials) > 0 {
+		var err error
+		gitCredentials, err = gitrepositoryutil.NewCredentials(credentials)
+		if err != nil {
+			return err
 		}
-		path := filepath.Clean(header.Name)
-		fullPath := filepath.Join(targetPath, path)
-		switch header.Typeflag {
-		case tar.TypeDir:
-			if err := os.MkdirAll(fullPath, 0755); err != nil {
-				return err
-			}
-		case tar.TypeReg:
-			if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
-				return err
-			}
-			outFile, err := os.Create(fullPath)
-			if err != nil {
-				return err
-			}
-			if err := func() error {
-				defer outFile.Close()
-				_, err := io.Copy(outFile, tarReader)
-				return err
-			}(); err != nil {
-				return err
-			}
-		default:
-			return fmt.Errorf("encountered unknown tar type while downloading URL: %v in %s", header.Typeflag, path)
+	}
+	return gitrepositoryutil.Checkout(url, gitCredentials, targetPath)
+}
+
+func (f *Factory) downloadArchive(url string, digest string, format string, credentials map[string][]byte, targetPath string) error {
+	var body io.ReadCloser
+	var contentType string
+	var err error
+	if ocirepositoryutil.IsArtifactUrl(url) {
+		// note: the digest of OCI artifacts is the manifest digest; the downloaded layer is verified against the layer digest
+		digest, err = ocirepositoryutil.GetLayerDigest(url)
+		if err != nil {
+			return err
 		}
+		body, err = openOciArtifact(url, credentials)
+	} else {
+		body, contentType, err = openHttpArtifact(url, credentials)
+	}
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	// note: digests which are not verifiable (such as ETags, returned by http repositories) are ignored
+	verifyingReader, err := newVerifyingReader(body, digest)
+	if err != nil {
+		return err
+	}
+
+	if err := extractArchive(verifyingReader, format, contentType, targetPath, f.archiveLimits, f.artifactCache.CreateTemp); err != nil {
+		return err
+	}
+
+	// note: the verifying reader reports a digest mismatch only when reaching the end of the stream; so the remaining
+	// data (such as trailing padding of the tar stream) must be consumed
+	if _, err := io.Copy(io.Discard, verifyingReader); err != nil {
+		return err
 	}
 
 	return nil
 }
 
+func openHttpArtifact(url string, credentials map[string][]byte) (io.ReadCloser, string, error) {
+	var httpCredentials *httprepositoryutil.Credentials
+	if len(credentials) > 0 {
+		var err error
+		httpCredentials, err = httprepositoryutil.NewCredentials(credentials)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	httpClient, err := httpCredentials.NewHttpClient()
+	if err != nil {
+		return nil, "", err
+	}
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	httpCredentials.Authorize(req)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, "", fmt.Errorf("error downloading %s: %s", url, resp.Status)
+	}
+	return resp.Body, resp.Header.Get("Content-Type"), nil
+}
+
+func openOciArtifact(url string, credentials map[string][]byte) (io.ReadCloser, error) {
+	var ociCredentials *ocirepositoryutil.Credentials
+	if len(credentials) > 0 {
+		var err error
+		ociCredentials, err = ocirepositoryutil.NewCredentials(credentials)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ocirepositoryutil.DownloadArtifact(url, ociCredentials)
+}
+
 func decryptDirectory(root *os.Root, path string, decryptor manifests.Decryptor) error {
 	if decryptor == nil {
 		return nil
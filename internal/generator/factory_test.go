@@ -0,0 +1,234 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package generator
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	operatorv1alpha1 "github.com/sap/component-operator/api/v1alpha1"
+	artifactcache "github.com/sap/component-operator/internal/cache/artifact"
+)
+
+// note: simulated latency of artifact downloads
+const testDownloadLatency = 20 * time.Millisecond
+
+// http server serving a small archive containing a plain manifest (for any path); before responding,
+// the handler calls the configured hook (if any)
+type testArtifactServer struct {
+	server   *httptest.Server
+	archive  []byte
+	requests atomic.Int32
+	hook     func()
+}
+
+func newTestArtifactServer(t testing.TB) *testArtifactServer {
+	s := &testArtifactServer{archive: newTestArchive(t)}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests.Add(1)
+		if s.hook != nil {
+			s.hook()
+		}
+		w.Header().Set("Content-Type", "application/gzip")
+		_, _ = w.Write(s.archive)
+	}))
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+// return the url of the i-th artifact; note that all artifacts have the same content, but different urls
+// (and therefore different digests), such that they result in different generator ids
+func (s *testArtifactServer) url(i int) string {
+	return fmt.Sprintf("%s/artifact-%d.tar.gz", s.server.URL, i)
+}
+
+func newTestArchive(t testing.TB) []byte {
+	manifest := []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\n")
+	buf := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	if err := tarWriter.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: "manifests/configmap.yaml", Mode: 0644, Size: int64(len(manifest))}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tarWriter.Write(manifest); err != nil {
+		t.Fatal(err)
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func newTestFactory(t testing.TB) *Factory {
+	artifactCache, err := artifactcache.New(t.TempDir(), 1024*1024*1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newFactory(nil, Options{ArtifactCache: artifactCache, CacheTTL: time.Hour})
+}
+
+func getTestGenerator(f *Factory, url string) error {
+	// note: the digest is not verifiable (like an ETag), so the artifact is downloaded for every generator
+	_, err := f.GetGenerator(url, "manifests", "etag-"+url, archiveFormatTarGzip, generatorConfig{Type: operatorv1alpha1.GeneratorPlain}, nil, "", nil)
+	return err
+}
+
+func TestGetGeneratorCoalescesRequestsForSameId(t *testing.T) {
+	server := newTestArtifactServer(t)
+	server.hook = func() { time.Sleep(testDownloadLatency) }
+	factory := newTestFactory(t)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for range 10 {
+		wg.Go(func() {
+			errs <- getTestGenerator(factory, server.url(0))
+		})
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := server.requests.Load(); n != 1 {
+		t.Errorf("expected artifact to be downloaded once, got %d downloads", n)
+	}
+}
+
+func TestGetGeneratorRunsDifferentIdsInParallel(t *testing.T) {
+	const n = 4
+	server := newTestArtifactServer(t)
+	// note: every download blocks until all n downloads are in flight; this can only succeed if the generators are created in parallel
+	var arrived sync.WaitGroup
+	arrived.Add(n)
+	allArrived := make(chan struct{})
+	go func() {
+		arrived.Wait()
+		close(allArrived)
+	}()
+	server.hook = func() {
+		arrived.Done()
+		select {
+		case <-allArrived:
+		case <-time.After(10 * time.Second):
+		}
+	}
+	factory := newTestFactory(t)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := range n {
+		wg.Go(func() {
+			errs <- getTestGenerator(factory, server.url(i))
+		})
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("generators for different ids were not created in parallel (took %s)", elapsed)
+	}
+}
+
+func TestGetGeneratorCountsHitsAndMissesOnce(t *testing.T) {
+	server := newTestArtifactServer(t)
+	factory := newTestFactory(t)
+
+	assertCounts := func(expectedHits float64, expectedMisses float64) {
+		t.Helper()
+		if hits := testutil.ToFloat64(cacheHits); hits != expectedHits {
+			t.Errorf("expected %v cache hits, got %v", expectedHits, hits)
+		}
+		if misses := testutil.ToFloat64(cacheMisses); misses != expectedMisses {
+			t.Errorf("expected %v cache misses, got %v", expectedMisses, misses)
+		}
+	}
+	hits := testutil.ToFloat64(cacheHits)
+	misses := testutil.ToFloat64(cacheMisses)
+
+	url := server.url(0)
+	if err := getTestGenerator(factory, url); err != nil {
+		t.Fatal(err)
+	}
+	assertCounts(hits, misses+1)
+
+	if err := getTestGenerator(factory, url); err != nil {
+		t.Fatal(err)
+	}
+	assertCounts(hits+1, misses+1)
+
+	// note: a cached generator whose external sources changed (here: cannot be resolved) is outdated, and must count as miss
+	id := generatorId(url, "manifests", "etag-"+url, archiveFormatTarGzip, generatorConfig{Type: operatorv1alpha1.GeneratorPlain}, "", nil)
+	factory.cache.add(id, &HelmGenerator{externalSources: []externalSource{{url: "invalid", digest: "invalid"}}}, 0)
+	if err := getTestGenerator(factory, url); err != nil {
+		t.Fatal(err)
+	}
+	assertCounts(hits+1, misses+2)
+	if n := server.requests.Load(); n != 2 {
+		t.Errorf("expected outdated generator to be recreated (2 downloads), got %d downloads", n)
+	}
+}
+
+// Compare the throughput of generator creation by concurrent reconcilers (for different generator ids) in the former
+// implementation (one factory-wide lock held during generator creation, emulated by the serialized variant) with the
+// current implementation (concurrent variant).
+func BenchmarkGetGenerator(b *testing.B) {
+	const concurrency = 8
+
+	for _, serialized := range []bool{true, false} {
+		name := "concurrent"
+		if serialized {
+			name = "serialized"
+		}
+		b.Run(name, func(b *testing.B) {
+			server := newTestArtifactServer(b)
+			server.hook = func() { time.Sleep(testDownloadLatency) }
+			factory := newTestFactory(b)
+			var mutex sync.Mutex
+			var next atomic.Int64
+
+			b.ResetTimer()
+			start := time.Now()
+			for range b.N {
+				var wg sync.WaitGroup
+				for range concurrency {
+					wg.Go(func() {
+						i := int(next.Add(1))
+						if serialized {
+							mutex.Lock()
+							defer mutex.Unlock()
+						}
+						if err := getTestGenerator(factory, server.url(i)); err != nil {
+							b.Error(err)
+						}
+					})
+				}
+				wg.Wait()
+			}
+			b.ReportMetric(float64(b.N*concurrency)/time.Since(start).Seconds(), "generators/s")
+		})
+	}
+}
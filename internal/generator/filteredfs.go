@@ -0,0 +1,94 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package generator
+
+import (
+	"io"
+	"io/fs"
+	"path"
+)
+
+// file system hiding all files and directories for which the given exclude function returns true; note that excluded
+// directories must also exclude their content (that is, exclude(name) must imply exclude(name + "/" + anything))
+type filteredFS struct {
+	fsys    fs.FS
+	exclude func(name string) bool
+}
+
+var _ fs.ReadDirFS = &filteredFS{}
+var _ fs.StatFS = &filteredFS{}
+
+func newFilteredFS(fsys fs.FS, exclude func(name string) bool) *filteredFS {
+	return &filteredFS{fsys: fsys, exclude: exclude}
+}
+
+func (f *filteredFS) Open(name string) (fs.File, error) {
+	if fs.ValidPath(name) && f.exclude(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	file, err := f.fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	if dir, ok := file.(fs.ReadDirFile); ok {
+		return &filteredDir{ReadDirFile: dir, fs: f, name: name}, nil
+	}
+	return file, nil
+}
+
+func (f *filteredFS) Stat(name string) (fs.FileInfo, error) {
+	if fs.ValidPath(name) && f.exclude(name) {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return fs.Stat(f.fsys, name)
+}
+
+func (f *filteredFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if fs.ValidPath(name) && f.exclude(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	entries, err := fs.ReadDir(f.fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	return f.filter(name, entries), nil
+}
+
+func (f *filteredFS) filter(dir string, entries []fs.DirEntry) []fs.DirEntry {
+	var result []fs.DirEntry
+	for _, entry := range entries {
+		if !f.exclude(path.Join(dir, entry.Name())) {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+type filteredDir struct {
+	fs.ReadDirFile
+	fs   *filteredFS
+	name string
+}
+
+func (d *filteredDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if n <= 0 {
+		entries, err := d.ReadDirFile.ReadDir(n)
+		return d.fs.filter(d.name, entries), err
+	}
+	// note: keep reading until n unfiltered entries are found (or the directory is exhausted)
+	var result []fs.DirEntry
+	for len(result) < n {
+		entries, err := d.ReadDirFile.ReadDir(n - len(result))
+		result = append(result, d.fs.filter(d.name, entries)...)
+		if err != nil {
+			if err == io.EOF && len(result) > 0 {
+				return result, nil
+			}
+			return result, err
+		}
+	}
+	return result, nil
+}
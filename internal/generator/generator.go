@@ -8,6 +8,9 @@ package generator
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/sap/go-generics/maps"
 
@@ -19,17 +22,50 @@ import (
 	componentoperatorruntimetypes "github.com/sap/component-operator-runtime/pkg/types"
 
 	operatorv1alpha1 "github.com/sap/component-operator/api/v1alpha1"
+	artifactcache "github.com/sap/component-operator/internal/cache/artifact"
 )
 
+// Generator options.
+type Options struct {
+	// Cache used to store downloaded source artifacts.
+	ArtifactCache *artifactcache.Cache
+	// Maximum total (uncompressed) size of downloaded archives; zero means no limit.
+	ArchiveMaxSize int64
+	// Maximum number of entries in downloaded archives; zero means no limit.
+	ArchiveMaxEntries int
+	// Maximum (uncompressed) size of a single file in downloaded archives; zero means no limit.
+	ArchiveMaxFileSize int64
+	// Time after which unused generators are evicted from the generator cache; must be positive.
+	CacheTTL time.Duration
+	// Maximum number of entries in the generator cache; zero means no limit.
+	CacheMaxEntries int
+	// Approximate maximum size (in bytes) of the entries in the generator cache; zero means no limit.
+	CacheMaxSize int64
+	// Disallow references to external sources (such as blueprints used as helm chart dependencies) in other namespaces,
+	// unless granted by the referenced object.
+	NoCrossNamespaceRefs bool
+}
+
 type Generator struct {
-	factory *Factory
+	factory              *Factory
+	noCrossNamespaceRefs bool
 }
 
 var _ manifests.Generator = &Generator{}
 
-func NewGenerator(clnt client.Client) (*Generator, error) {
+func NewGenerator(clnt client.Client, options Options) (*Generator, error) {
+	if options.ArtifactCache == nil {
+		return nil, fmt.Errorf("missing artifact cache")
+	}
+	if options.ArchiveMaxSize < 0 || options.ArchiveMaxEntries < 0 || options.ArchiveMaxFileSize < 0 {
+		return nil, fmt.Errorf("invalid archive limits (must not be negative)")
+	}
+	if options.CacheTTL <= 0 {
+		return nil, fmt.Errorf("invalid generator cache ttl: %s (must be positive)", options.CacheTTL)
+	}
 	return &Generator{
-		factory: newFactory(clnt),
+		factory:              newFactory(clnt, options),
+		noCrossNamespaceRefs: options.NoCrossNamespaceRefs,
 	}, nil
 }
 
@@ -43,6 +79,7 @@ func (g *Generator) Generate(ctx context.Context, namespace string, name string,
 
 	url := spec.SourceRef.Artifact().Url
 	digest := spec.SourceRef.Artifact().Digest
+	format := spec.SourceRef.Artifact().Format
 	path := spec.Path
 
 	var decryptionProvider string
@@ -52,35 +89,100 @@ func (g *Generator) Generate(ctx context.Context, namespace string, name string,
 		decryptionKeys = spec.Decryption.SecretRef.Data()
 	}
 
-	generator, err := g.factory.GetGenerator(url, path, digest, decryptionProvider, decryptionKeys)
+	var sourceCredentials map[string][]byte
+	switch {
+	case spec.SourceRef.HttpRepository != nil && spec.SourceRef.HttpRepository.SecretRef != nil:
+		sourceCredentials = spec.SourceRef.HttpRepository.SecretRef.Data()
+	case spec.SourceRef.OciRepository != nil && spec.SourceRef.OciRepository.SecretRef != nil:
+		sourceCredentials = spec.SourceRef.OciRepository.SecretRef.Data()
+	case spec.SourceRef.GitRepository != nil && spec.SourceRef.GitRepository.SecretRef != nil:
+		sourceCredentials = spec.SourceRef.GitRepository.SecretRef.Data()
+	}
+
+	config := generatorConfig{
+		Type:    spec.Generator,
+		Helm:    spec.Helm,
+		Plain:   spec.Plain,
+		Jsonnet: spec.Jsonnet,
+		Cue:     spec.Cue,
+	}
+
+	generator, err := g.factory.GetGenerator(url, path, digest, format, config, sourceCredentials, decryptionProvider, decryptionKeys)
 	if err != nil {
 		return nil, err
 	}
+	if g.noCrossNamespaceRefs {
+		c, err := component.ComponentFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := g.factory.checkExternalSourceGrants(generator, c.GetNamespace()); err != nil {
+			return nil, err
+		}
+	}
 
+	merger, err := newValuesMerger(spec.MergeStrategy)
+	if err != nil {
+		return nil, err
+	}
 	values := make(map[string]any)
 	for _, ref := range spec.ValuesFrom {
-		var v map[string]any
-		if err := kyaml.Unmarshal(ref.Value(), &v); err != nil {
-			return nil, err
+		v, err := valuesFromReference(&ref)
+		if err != nil {
+			return nil, fmt.Errorf("invalid values in valuesFrom reference %s: %w", ref.Name, err)
+		}
+		refMerger := merger
+		if ref.MergeStrategy != nil {
+			refMerger, err = newValuesMerger(spec.MergeStrategy, ref.MergeStrategy)
+			if err != nil {
+				return nil, err
+			}
+		}
+		refMerger.merge(values, v)
+	}
+	for _, dependency := range spec.Dependencies {
+		for _, imp := range dependency.Imports {
+			if imp.TargetPath == "" {
+				continue
+			}
+			v, err := valuesFromImport(&dependency, &imp)
+			if err != nil {
+				return nil, fmt.Errorf("invalid import of export %s from dependency %s: %w", imp.Export, dependency.Name, err)
+			}
+			merger.merge(values, v)
 		}
-		deepMerge(values, v)
 	}
 	if spec.Values != nil {
 		var v map[string]any
 		if err := json.Unmarshal(spec.Values.Raw, &v); err != nil {
 			return nil, err
 		}
-		deepMerge(values, v)
+		merger.merge(values, v)
 	}
 
-	if spec.PostBuild != nil {
+	importedSubstitutions := make(map[string]string)
+	for _, dependency := range spec.Dependencies {
+		for _, imp := range dependency.Imports {
+			if imp.Variable == "" {
+				continue
+			}
+			importedSubstitutions[imp.Variable] = substitutionFromImport(&dependency, &imp)
+		}
+	}
+
+	if spec.PostBuild != nil || len(importedSubstitutions) > 0 {
+		postBuild := spec.PostBuild
+		if postBuild == nil {
+			postBuild = &operatorv1alpha1.PostBuild{}
+		}
 		transformableGenerator := manifests.NewGenerator(generator)
-		if len(spec.PostBuild.Substitute) > 0 || len(spec.PostBuild.SubstituteFrom) > 0 {
+		if len(postBuild.Substitute) > 0 || len(postBuild.SubstituteFrom) > 0 || len(importedSubstitutions) > 0 {
 			substitutions := make(map[string]string)
-			for _, ref := range spec.PostBuild.SubstituteFrom {
+			for _, ref := range postBuild.SubstituteFrom {
 				shallowMerge(substitutions, maps.Collect(ref.Data(), func(x []byte) string { return string(x) }))
 			}
-			shallowMerge(substitutions, spec.PostBuild.Substitute)
+			shallowMerge(substitutions, importedSubstitutions)
+			shallowMerge(substitutions, postBuild.Substitute)
 			transformer, err := manifests.NewSubstitutionObjectTransformer(substitutions, componentoperatorruntimetypes.SelectorFunc[client.Object](func(object client.Object) bool {
 				return object.GetAnnotations()[reconcilerName+"/disableSubstitution"] != "true"
 			}))
@@ -89,8 +191,8 @@ func (g *Generator) Generate(ctx context.Context, namespace string, name string,
 			}
 			transformableGenerator.WithObjectTransformer(transformer)
 		}
-		if len(spec.PostBuild.Patches) > 0 || len(spec.PostBuild.Images) > 0 {
-			transformer, err := manifests.NewKustomizeObjectTransformer(spec.PostBuild.Patches, spec.PostBuild.Images)
+		if len(postBuild.Patches) > 0 || len(postBuild.Images) > 0 {
+			transformer, err := manifests.NewKustomizeObjectTransformer(postBuild.Patches, postBuild.Images)
 			if err != nil {
 				return nil, err
 			}
@@ -106,3 +208,65 @@ func (g *Generator) Generate(ctx context.Context, namespace string, name string,
 
 	return objects, nil
 }
+
+// return the values provided by the given (loaded) values reference
+func valuesFromReference(ref *operatorv1alpha1.ValuesReference) (map[string]any, error) {
+	value := ref.Value()
+	if value == nil {
+		return nil, nil
+	}
+	if ref.TargetPath == "" {
+		var v map[string]any
+		if err := kyaml.Unmarshal(value, &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+	p, err := parseValuePath(ref.TargetPath)
+	if err != nil {
+		return nil, err
+	}
+	var v any
+	if err := kyaml.Unmarshal(value, &v); err != nil {
+		v = nil
+	}
+	switch v.(type) {
+	case map[string]any, []any:
+	default:
+		// note: scalars are inserted as (raw) strings, since parsing them as YAML would e.g. turn a version 1.10 into the number 1.1
+		v = strings.TrimSuffix(string(value), "\n")
+	}
+	values := make(map[string]any)
+	if err := setValue(values, p, v); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// return the values provided by the given import of the given (loaded) dependency
+func valuesFromImport(dependency *operatorv1alpha1.Dependency, imp *operatorv1alpha1.Import) (map[string]any, error) {
+	p, err := parseValuePath(imp.TargetPath)
+	if err != nil {
+		return nil, err
+	}
+	var v any
+	if err := json.Unmarshal(dependency.ExportedValue(imp.Export), &v); err != nil {
+		return nil, err
+	}
+	values := make(map[string]any)
+	if err := setValue(values, p, v); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// return the substitution value for the given import of the given (loaded) dependency; strings are returned as they are,
+// other values in their (compact) JSON representation
+func substitutionFromImport(dependency *operatorv1alpha1.Dependency, imp *operatorv1alpha1.Import) string {
+	value := dependency.ExportedValue(imp.Export)
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		return s
+	}
+	return string(value)
+}
@@ -0,0 +1,472 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package generator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	kyaml "sigs.k8s.io/yaml"
+
+	"github.com/sap/component-operator-runtime/pkg/cluster"
+	"github.com/sap/component-operator-runtime/pkg/component"
+	"github.com/sap/component-operator-runtime/pkg/manifests"
+	"github.com/sap/component-operator-runtime/pkg/manifests/helm"
+	componentoperatorruntimetypes "github.com/sap/component-operator-runtime/pkg/types"
+
+	operatorv1alpha1 "github.com/sap/component-operator/api/v1alpha1"
+)
+
+// HelmGenerator wraps the helm generator of component-operator-runtime, adding support for a custom release name,
+// typed value overrides, strict validation of the values against the chart's values.schema.json, and overrides
+// of the capabilities (kube version, api versions) exposed to the chart templates.
+// In addition, dependencies declared in Chart.yaml are handled: subcharts are renamed according to their alias,
+// and disabled (by condition or tags) subcharts are hidden from the underlying generator. Note that all dependencies
+// have to be present in the according charts directory (as directories).
+type HelmGenerator struct {
+	// note: the underlying generator if the chart has no dependencies
+	generator manifests.Generator
+	// note: the chart tree (and the underlying generators, by disabled dependencies) if the chart has dependencies
+	fsys          *memFS
+	chartPath     string
+	dependencies  []*helmDependency
+	chartValues   map[string][]byte
+	crds          operatorv1alpha1.HelmCrdPolicy
+	mutex         sync.Mutex
+	generators    map[string]manifests.Generator
+	releaseName   string
+	overrides     []helmValueOverride
+	defaultValues []byte
+	schema        *jsonschema.Schema
+	kubeVersion   *version.Info
+	apiVersions   []string
+	// note: set by the factory, if dependencies were resolved from blueprints or other components
+	externalSources []externalSource
+}
+
+var _ manifests.Generator = &HelmGenerator{}
+
+type helmValueOverride struct {
+	path  []any
+	value []byte
+}
+
+// Create a new HelmGenerator for the chart at the given path (relative to the root of fsys). All files needed
+// by the wrapping logic are read upon creation; fsys is not accessed afterwards (by the wrapper).
+func NewHelmGenerator(fsys fs.FS, chartPath string, options *operatorv1alpha1.HelmGeneratorOptions) (*HelmGenerator, error) {
+	if options == nil {
+		options = &operatorv1alpha1.HelmGeneratorOptions{}
+	}
+	chartPath = path.Clean(chartPath)
+
+	g := &HelmGenerator{
+		releaseName: options.ReleaseName,
+	}
+
+	for _, override := range options.Set {
+		p, err := parseValuePath(override.Path)
+		if err != nil {
+			return nil, err
+		}
+		g.overrides = append(g.overrides, helmValueOverride{path: p, value: override.Value.Raw})
+	}
+
+	if options.StrictValuesValidation {
+		rawSchema, err := fs.ReadFile(fsys, path.Join(chartPath, "values.schema.json"))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		if err == nil {
+			doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(rawSchema))
+			if err != nil {
+				return nil, fmt.Errorf("error parsing values.schema.json: %w", err)
+			}
+			compiler := jsonschema.NewCompiler()
+			// note: schemas must not load (arbitrary) remote references
+			compiler.UseLoader(jsonschema.SchemeURLLoader{})
+			if err := compiler.AddResource("values.schema.json", doc); err != nil {
+				return nil, fmt.Errorf("error loading values.schema.json: %w", err)
+			}
+			g.schema, err = compiler.Compile("values.schema.json")
+			if err != nil {
+				return nil, fmt.Errorf("error compiling values.schema.json: %w", err)
+			}
+			rawValues, err := fs.ReadFile(fsys, path.Join(chartPath, "values.yaml"))
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return nil, err
+			}
+			if err == nil {
+				g.defaultValues, err = kyaml.YAMLToJSON(rawValues)
+				if err != nil {
+					return nil, fmt.Errorf("error parsing values.yaml: %w", err)
+				}
+			}
+		}
+	}
+
+	if options.KubeVersion != "" {
+		v, err := utilversion.ParseGeneric(options.KubeVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kube version %s: %w", options.KubeVersion, err)
+		}
+		gitVersion := options.KubeVersion
+		if !strings.HasPrefix(gitVersion, "v") {
+			gitVersion = "v" + gitVersion
+		}
+		g.kubeVersion = &version.Info{
+			Major:      strconv.Itoa(int(v.Major())),
+			Minor:      strconv.Itoa(int(v.Minor())),
+			GitVersion: gitVersion,
+		}
+	}
+	for _, apiVersion := range options.ApiVersions {
+		if _, _, err := parseHelmApiVersion(apiVersion); err != nil {
+			return nil, err
+		}
+		g.apiVersions = append(g.apiVersions, apiVersion)
+	}
+
+	hasDependencies, err := helmChartHasDependencies(fsys, chartPath)
+	if err != nil {
+		return nil, err
+	}
+	if hasDependencies {
+		g.fsys = newMemFS()
+		g.chartPath = chartPath
+		g.chartValues = make(map[string][]byte)
+		g.crds = options.Crds
+		g.generators = make(map[string]manifests.Generator)
+		if err := g.loadChart(fsys, chartPath, chartPath, "", nil, 0); err != nil {
+			return nil, err
+		}
+		// note: create the generator with all dependencies enabled, in order to report errors early
+		if _, err := g.getGenerator(nil); err != nil {
+			return nil, err
+		}
+	} else {
+		if options.Crds == operatorv1alpha1.HelmCrdPolicySkip {
+			fsys = newFilteredFS(fsys, func(name string) bool {
+				return isHelmCrdPath(chartPath, name)
+			})
+		}
+		g.generator, err = helm.NewHelmGenerator(fsys, chartPath, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return g, nil
+}
+
+// Implement the manifests.Generator interface.
+func (g *HelmGenerator) Generate(ctx context.Context, namespace string, name string, parameters componentoperatorruntimetypes.Unstructurable) ([]client.Object, error) {
+	if g.releaseName != "" {
+		name = g.releaseName
+	}
+
+	var values map[string]any
+	// note: the values are copied, since overrides must not modify the passed parameters
+	if err := copyJSON(parameters.ToUnstructured(), &values); err != nil {
+		return nil, err
+	}
+	if values == nil {
+		values = make(map[string]any)
+	}
+	for _, override := range g.overrides {
+		var value any
+		if err := json.Unmarshal(override.value, &value); err != nil {
+			return nil, err
+		}
+		if err := setValue(values, override.path, value); err != nil {
+			return nil, err
+		}
+	}
+
+	if g.schema != nil {
+		coalescedValues, err := coalesceHelmValues(g.defaultValues, values)
+		if err != nil {
+			return nil, err
+		}
+		// note: invalid values will not become valid by requeueing; the next attempt happens when the component or the chart changes
+		if err := g.schema.Validate(any(coalescedValues)); err != nil {
+			var validationErr *jsonschema.ValidationError
+			if errors.As(err, &validationErr) {
+				return nil, reconcile.TerminalError(fmt.Errorf("values do not conform to values.schema.json: %s", formatJsonSchemaError(validationErr)))
+			}
+			return nil, reconcile.TerminalError(fmt.Errorf("values do not conform to values.schema.json: %w", err))
+		}
+	}
+
+	if g.kubeVersion != nil || len(g.apiVersions) > 0 {
+		clnt, err := component.ClientFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ctx = component.NewContext(ctx).WithClient(&capabilitiesClient{
+			Client: clnt,
+			discoveryClient: &capabilitiesDiscoveryClient{
+				DiscoveryInterface: clnt.DiscoveryClient(),
+				kubeVersion:        g.kubeVersion,
+				apiVersions:        g.apiVersions,
+			},
+		})
+	}
+
+	generator := g.generator
+	if generator == nil {
+		disabledDependencies, err := g.disabledDependencies(values)
+		if err != nil {
+			return nil, err
+		}
+		generator, err = g.getGenerator(disabledDependencies)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return generator.Generate(ctx, namespace, name, componentoperatorruntimetypes.UnstructurableMap(values))
+}
+
+// return the underlying generator for the given set of disabled dependencies (identified by their directories)
+func (g *HelmGenerator) getGenerator(disabledDependencies []string) (manifests.Generator, error) {
+	key := strings.Join(disabledDependencies, "\n")
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if generator, ok := g.generators[key]; ok {
+		return generator, nil
+	}
+	fsys := newFilteredFS(g.fsys, func(name string) bool {
+		if g.crds == operatorv1alpha1.HelmCrdPolicySkip && isHelmCrdPath(g.chartPath, name) {
+			return true
+		}
+		for _, dir := range disabledDependencies {
+			if name == dir || strings.HasPrefix(name, dir+"/") {
+				return true
+			}
+		}
+		return false
+	})
+	generator, err := helm.NewHelmGenerator(fsys, g.chartPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	g.generators[key] = generator
+	return generator, nil
+}
+
+func copyJSON(in any, out any) error {
+	raw, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, out)
+}
+
+func formatJsonSchemaError(err *jsonschema.ValidationError) string {
+	var messages []string
+	var collect func(unit *jsonschema.OutputUnit)
+	collect = func(unit *jsonschema.OutputUnit) {
+		if unit.Error != nil {
+			location := unit.InstanceLocation
+			if location == "" {
+				location = "/"
+			}
+			messages = append(messages, fmt.Sprintf("%s: %s", location, unit.Error.String()))
+		}
+		for i := range unit.Errors {
+			collect(&unit.Errors[i])
+		}
+	}
+	collect(err.BasicOutput())
+	if len(messages) == 0 {
+		return err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// check whether name (a path relative to the root of the filesystem) is (or is contained in) the crds directory
+// of the chart at chartPath, or of one of its (unpacked) subcharts
+func isHelmCrdPath(chartPath string, name string) bool {
+	if chartPath != "." {
+		if !strings.HasPrefix(name, chartPath+"/") {
+			return false
+		}
+		name = strings.TrimPrefix(name, chartPath+"/")
+	}
+	return helmCrdPathPattern.MatchString(name)
+}
+
+var helmCrdPathPattern = regexp.MustCompile(`^(charts/[^/]+/)*crds(/.*)?$`)
+
+// parse an entry of HelmGeneratorOptions.ApiVersions
+func parseHelmApiVersion(apiVersion string) (schema.GroupVersion, string, error) {
+	parts := strings.Split(apiVersion, "/")
+	for _, part := range parts {
+		if part == "" {
+			return schema.GroupVersion{}, "", fmt.Errorf("invalid api version: %s", apiVersion)
+		}
+	}
+	switch len(parts) {
+	case 1:
+		return schema.GroupVersion{Version: parts[0]}, "", nil
+	case 2:
+		// note: <version>/<kind> (such as v1/Service) denotes a kind of the core group; kinds are recognized by their
+		// leading upper case letter
+		if parts[1][0] >= 'A' && parts[1][0] <= 'Z' {
+			return schema.GroupVersion{Version: parts[0]}, parts[1], nil
+		}
+		return schema.GroupVersion{Group: parts[0], Version: parts[1]}, "", nil
+	case 3:
+		return schema.GroupVersion{Group: parts[0], Version: parts[1]}, parts[2], nil
+	default:
+		return schema.GroupVersion{}, "", fmt.Errorf("invalid api version: %s", apiVersion)
+	}
+}
+
+// client overriding the discovery client (and passing through everything else)
+type capabilitiesClient struct {
+	cluster.Client
+	discoveryClient discovery.DiscoveryInterface
+}
+
+func (c *capabilitiesClient) DiscoveryClient() discovery.DiscoveryInterface {
+	return c.discoveryClient
+}
+
+// discovery client reporting a fixed server version (if set), and additional api versions (besides the ones served)
+type capabilitiesDiscoveryClient struct {
+	discovery.DiscoveryInterface
+	kubeVersion *version.Info
+	apiVersions []string
+}
+
+func (c *capabilitiesDiscoveryClient) ServerVersion() (*version.Info, error) {
+	if c.kubeVersion != nil {
+		kubeVersion := *c.kubeVersion
+		return &kubeVersion, nil
+	}
+	return c.DiscoveryInterface.ServerVersion()
+}
+
+func (c *capabilitiesDiscoveryClient) ServerGroups() (*metav1.APIGroupList, error) {
+	groupList, err := c.DiscoveryInterface.ServerGroups()
+	if err != nil {
+		return nil, err
+	}
+	groupList = groupList.DeepCopy()
+	groups := make([]*metav1.APIGroup, len(groupList.Groups))
+	for i := range groupList.Groups {
+		groups[i] = &groupList.Groups[i]
+	}
+	groups, _ = c.extend(groups, nil)
+	groupList.Groups = nil
+	for _, group := range groups {
+		groupList.Groups = append(groupList.Groups, *group)
+	}
+	return groupList, nil
+}
+
+func (c *capabilitiesDiscoveryClient) ServerGroupsAndResources() ([]*metav1.APIGroup, []*metav1.APIResourceList, error) {
+	groups, resourceLists, err := c.DiscoveryInterface.ServerGroupsAndResources()
+	// note: partial results are returned along with an error of type ErrGroupDiscoveryFailed, so they have to be extended as well
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, nil, err
+	}
+	for i := range groups {
+		groups[i] = groups[i].DeepCopy()
+	}
+	for i := range resourceLists {
+		resourceLists[i] = resourceLists[i].DeepCopy()
+	}
+	groups, resourceLists = c.extend(groups, resourceLists)
+	return groups, resourceLists, err
+}
+
+func (c *capabilitiesDiscoveryClient) extend(groups []*metav1.APIGroup, resourceLists []*metav1.APIResourceList) ([]*metav1.APIGroup, []*metav1.APIResourceList) {
+	for _, apiVersion := range c.apiVersions {
+		gv, kind, err := parseHelmApiVersion(apiVersion)
+		if err != nil {
+			// note: api versions were validated upon creation of the generator
+			panic("this cannot happen")
+		}
+
+		var group *metav1.APIGroup
+		for _, g := range groups {
+			if g.Name == gv.Group {
+				group = g
+				break
+			}
+		}
+		if group == nil {
+			group = &metav1.APIGroup{Name: gv.Group}
+			groups = append(groups, group)
+		}
+		found := false
+		for _, v := range group.Versions {
+			if v.Version == gv.Version {
+				found = true
+				break
+			}
+		}
+		if !found {
+			groupVersion := metav1.GroupVersionForDiscovery{GroupVersion: gv.String(), Version: gv.Version}
+			group.Versions = append(group.Versions, groupVersion)
+			if group.PreferredVersion.Version == "" {
+				group.PreferredVersion = groupVersion
+			}
+		}
+
+		var resourceList *metav1.APIResourceList
+		for _, l := range resourceLists {
+			if l.GroupVersion == gv.String() {
+				resourceList = l
+				break
+			}
+		}
+		if resourceList == nil {
+			resourceList = &metav1.APIResourceList{GroupVersion: gv.String()}
+			resourceLists = append(resourceLists, resourceList)
+		}
+		if kind != "" {
+			found := false
+			for _, r := range resourceList.APIResources {
+				if r.Kind == kind {
+					found = true
+					break
+				}
+			}
+			if !found {
+				resourceList.APIResources = append(resourceList.APIResources, metav1.APIResource{
+					Name:    strings.ToLower(kind),
+					Kind:    kind,
+					Group:   gv.Group,
+					Version: gv.Version,
+				})
+			}
+		}
+	}
+	return groups, resourceLists
+}
@@ -0,0 +1,725 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	kyaml "sigs.k8s.io/yaml"
+
+	componentoperatorruntimetypes "github.com/sap/component-operator-runtime/pkg/types"
+
+	operatorv1alpha1 "github.com/sap/component-operator/api/v1alpha1"
+	"github.com/sap/component-operator/internal/reference"
+)
+
+// maximum nesting level of helm chart dependencies (protects against circular dependencies)
+const maxHelmDependencyDepth = 10
+
+var helmExternalRepositoryPattern = regexp.MustCompile(`^(blueprint|component)://([^/]+)/([^/]+)(/.*)?$`)
+
+// the relevant parts of a Chart.yaml file
+type helmChartMetadata struct {
+	Name         string                `json:"name"`
+	Dependencies []helmChartDependency `json:"dependencies,omitempty"`
+}
+
+type helmChartDependency struct {
+	Name       string   `json:"name"`
+	Version    string   `json:"version,omitempty"`
+	Repository string   `json:"repository,omitempty"`
+	Condition  string   `json:"condition,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	Alias      string   `json:"alias,omitempty"`
+}
+
+// location of a chart within a source artifact; used to resolve relative dependency repositories
+type helmChartSource struct {
+	fsys fs.FS
+	dir  string
+}
+
+// reference to a source outside of the artifact (a blueprint or the source of another component), as used by a generator;
+// the digest is used to detect whether the generator has to be recreated
+type externalSource struct {
+	url    string
+	digest string
+}
+
+func readHelmChartMetadata(fsys fs.FS, dir string) (*helmChartMetadata, error) {
+	raw, err := fs.ReadFile(fsys, path.Join(dir, "Chart.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	metadata := &helmChartMetadata{}
+	if err := kyaml.Unmarshal(raw, metadata); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", path.Join(dir, "Chart.yaml"), err)
+	}
+	for _, dependency := range metadata.Dependencies {
+		if dependency.Name == "" {
+			return nil, fmt.Errorf("error parsing %s: dependency without name", path.Join(dir, "Chart.yaml"))
+		}
+		for _, name := range []string{dependency.Name, dependency.Alias} {
+			if name != "" && !isValidHelmChartName(name) {
+				return nil, fmt.Errorf("error parsing %s: invalid dependency name or alias: %s", path.Join(dir, "Chart.yaml"), name)
+			}
+		}
+	}
+	return metadata, nil
+}
+
+// check whether name is usable as directory name of a subchart
+func isValidHelmChartName(name string) bool {
+	return fs.ValidPath(name) && !strings.Contains(name, "/") && name != "."
+}
+
+// return the subcharts (directories containing a Chart.yaml file) of the chart at dir, as map from chart name to directory name,
+// and the names of the chart archives contained in the charts directory
+func listHelmSubcharts(fsys fs.FS, dir string) (map[string]string, []string, error) {
+	subcharts := make(map[string]string)
+	var archives []string
+	entries, err := fs.ReadDir(fsys, path.Join(dir, "charts"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return subcharts, nil, nil
+		}
+		return nil, nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			metadata, err := readHelmChartMetadata(fsys, path.Join(dir, "charts", entry.Name()))
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					continue
+				}
+				return nil, nil, err
+			}
+			if _, ok := subcharts[metadata.Name]; !ok {
+				subcharts[metadata.Name] = entry.Name()
+			}
+		} else if strings.HasSuffix(entry.Name(), ".tgz") {
+			archives = append(archives, entry.Name())
+		}
+	}
+	return subcharts, archives, nil
+}
+
+// check whether the chart at dir, or one of its subcharts (recursively), declares dependencies
+func helmChartHasDependencies(fsys fs.FS, dir string) (bool, error) {
+	metadata, err := readHelmChartMetadata(fsys, dir)
+	if err != nil {
+		return false, err
+	}
+	if len(metadata.Dependencies) > 0 {
+		return true, nil
+	}
+	subcharts, _, err := listHelmSubcharts(fsys, dir)
+	if err != nil {
+		return false, err
+	}
+	for _, subchart := range subcharts {
+		hasDependencies, err := helmChartHasDependencies(fsys, path.Join(dir, "charts", subchart))
+		if err != nil {
+			return false, err
+		}
+		if hasDependencies {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// make sure that all dependencies declared by the chart at dir (within root), and by its subcharts (recursively), are present
+// as directories in the according charts directory; dependencies which are not yet present are extracted from chart archives
+// contained in the charts directory, or fetched from their repository; supported repositories are file:// and relative paths
+// (resolved within the source of the declaring chart), blueprint://<namespace>/<name>[/<path>] and component://<namespace>/<name>[/<path>]
+func (f *Factory) vendorHelmDependencies(root *os.Root, dir string, source helmChartSource, depth int, externalSources *[]externalSource) error {
+	if depth > maxHelmDependencyDepth {
+		return fmt.Errorf("chart dependencies nested too deeply (possibly circular) at %s", dir)
+	}
+	metadata, err := readHelmChartMetadata(root.FS(), dir)
+	if err != nil {
+		return err
+	}
+	subcharts, archives, err := listHelmSubcharts(root.FS(), dir)
+	if err != nil {
+		return err
+	}
+	// note: subcharts which were fetched (and whose dependencies were vendored) in this invocation
+	processed := make(map[string]bool)
+
+	for _, dependency := range metadata.Dependencies {
+		if _, ok := subcharts[dependency.Name]; ok {
+			continue
+		}
+		target := path.Join(dir, "charts", dependency.Name)
+		if _, err := root.Stat(target); err == nil {
+			return fmt.Errorf("unable to vendor dependency %s of chart %s: %s already exists", dependency.Name, metadata.Name, target)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+
+		if archive := findHelmChartArchive(archives, dependency.Name); archive != "" {
+			if err := f.extractHelmChartArchive(root, path.Join(dir, "charts", archive), target, dependency.Name); err != nil {
+				return fmt.Errorf("error extracting dependency %s of chart %s: %w", dependency.Name, metadata.Name, err)
+			}
+			subcharts[dependency.Name] = dependency.Name
+			continue
+		}
+
+		repository := dependency.Repository
+		switch {
+		case repository == "":
+			return fmt.Errorf("dependency %s of chart %s is neither contained in the charts directory, nor does it specify a repository", dependency.Name, metadata.Name)
+		case strings.HasPrefix(repository, "file://") || (!strings.Contains(repository, "://") && !strings.HasPrefix(repository, "@")):
+			p := strings.TrimPrefix(repository, "file://")
+			if path.IsAbs(p) {
+				return fmt.Errorf("invalid repository for dependency %s of chart %s: absolute paths are not allowed: %s", dependency.Name, metadata.Name, repository)
+			}
+			p = path.Join(source.dir, p)
+			if !fs.ValidPath(p) {
+				return fmt.Errorf("invalid repository for dependency %s of chart %s: path points outside of the artifact: %s", dependency.Name, metadata.Name, repository)
+			}
+			if err := copyHelmChart(root, target, source.fsys, p, dependency.Name); err != nil {
+				return fmt.Errorf("error copying dependency %s of chart %s: %w", dependency.Name, metadata.Name, err)
+			}
+			if err := f.vendorHelmDependencies(root, target, helmChartSource{fsys: source.fsys, dir: p}, depth+1, externalSources); err != nil {
+				return err
+			}
+		case helmExternalRepositoryPattern.MatchString(repository):
+			if err := f.withExternalHelmChartSource(repository, externalSources, func(externalSource helmChartSource) error {
+				if err := copyHelmChart(root, target, externalSource.fsys, externalSource.dir, dependency.Name); err != nil {
+					return fmt.Errorf("error copying dependency %s of chart %s: %w", dependency.Name, metadata.Name, err)
+				}
+				// note: the external source is only available within this function, so dependencies have to be processed here
+				return f.vendorHelmDependencies(root, target, externalSource, depth+1, externalSources)
+			}); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unsupported repository for dependency %s of chart %s: %s (supported are file://, relative paths, blueprint:// and component://; other dependencies must be contained in the charts directory)", dependency.Name, metadata.Name, repository)
+		}
+		subcharts[dependency.Name] = dependency.Name
+		processed[dependency.Name] = true
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(subcharts)) {
+		if processed[name] {
+			continue
+		}
+		subchart := path.Join(dir, "charts", subcharts[name])
+		// note: subcharts extracted from archives have no meaningful source location, so relative repositories are resolved
+		// within the extracted directory
+		subchartSource := helmChartSource{fsys: source.fsys, dir: path.Join(source.dir, "charts", subcharts[name])}
+		if _, err := fs.Stat(source.fsys, path.Join(subchartSource.dir, "Chart.yaml")); err != nil {
+			subchartSource = helmChartSource{fsys: root.FS(), dir: subchart}
+		}
+		if err := f.vendorHelmDependencies(root, subchart, subchartSource, depth+1, externalSources); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// find the archive of the given chart (named <name>-<version>.tgz) among the given file names
+func findHelmChartArchive(archives []string, name string) string {
+	for _, archive := range archives {
+		if version, ok := strings.CutPrefix(strings.TrimSuffix(archive, ".tgz"), name+"-"); ok && version != "" && version[0] >= '0' && version[0] <= '9' {
+			return archive
+		}
+	}
+	return ""
+}
+
+// extract a chart archive (within root) to the given target directory; chart archives contain a single top-level directory
+func (f *Factory) extractHelmChartArchive(root *os.Root, archive string, target string, name string) error {
+	staging := path.Join(path.Dir(target), "."+path.Base(target)+".tmp")
+	if err := root.MkdirAll(staging, 0755); err != nil {
+		return err
+	}
+	defer root.RemoveAll(staging)
+	file, err := root.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := extractArchive(file, archiveFormatTarGzip, "", filepath.Join(root.Name(), filepath.FromSlash(staging)), f.archiveLimits, f.artifactCache.CreateTemp); err != nil {
+		return err
+	}
+	entries, err := fs.ReadDir(root.FS(), staging)
+	if err != nil {
+		return err
+	}
+	if len(entries) != 1 || !entries[0].IsDir() {
+		return fmt.Errorf("invalid chart archive %s: expected exactly one top-level directory", path.Base(archive))
+	}
+	if err := checkHelmChartName(root.FS(), path.Join(staging, entries[0].Name()), name); err != nil {
+		return err
+	}
+	if err := root.Rename(path.Join(staging, entries[0].Name()), target); err != nil {
+		return err
+	}
+	return root.Remove(archive)
+}
+
+// copy the chart at dir (within fsys) to the given target directory (within root)
+func copyHelmChart(root *os.Root, target string, fsys fs.FS, dir string, name string) error {
+	if err := checkHelmChartName(fsys, dir, name); err != nil {
+		return err
+	}
+	return copyDirectory(fsys, dir, filepath.Join(root.Name(), filepath.FromSlash(target)))
+}
+
+func checkHelmChartName(fsys fs.FS, dir string, name string) error {
+	metadata, err := readHelmChartMetadata(fsys, dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("no Chart.yaml found in %s", dir)
+		}
+		return err
+	}
+	if metadata.Name != name {
+		return fmt.Errorf("chart in %s has name %s (expected %s)", dir, metadata.Name, name)
+	}
+	return nil
+}
+
+// make the given external source (blueprint://<namespace>/<name>[/<path>] or component://<namespace>/<name>[/<path>]) available
+// while executing fn; the source is recorded in externalSources
+func (f *Factory) withExternalHelmChartSource(repository string, externalSources *[]externalSource, fn func(source helmChartSource) error) error {
+	m := helmExternalRepositoryPattern.FindStringSubmatch(repository)
+	if m == nil {
+		return fmt.Errorf("invalid repository: %s", repository)
+	}
+	url := fmt.Sprintf("%s://%s/%s", m[1], m[2], m[3])
+	subPath := strings.TrimPrefix(m[4], "/")
+
+	var dir, digest string
+	switch m[1] {
+	case "blueprint":
+		blueprint := &operatorv1alpha1.Blueprint{}
+		if err := f.client.Get(context.TODO(), apitypes.NamespacedName{Namespace: m[2], Name: m[3]}, blueprint); err != nil {
+			return err
+		}
+		tmpdir, err := os.MkdirTemp("", "component-operator-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmpdir)
+		if err := writeBlueprintFiles(blueprint.Spec.Files, tmpdir); err != nil {
+			return err
+		}
+		dir = tmpdir
+		digest = blueprint.GetDigest()
+	case "component":
+		component := &operatorv1alpha1.Component{}
+		if err := f.client.Get(context.TODO(), apitypes.NamespacedName{Namespace: m[2], Name: m[3]}, component); err != nil {
+			return err
+		}
+		if component.Status.SourceRef == nil || component.Status.SourceRef.Artifact.Url == "" {
+			return componentoperatorruntimetypes.NewRetriableError(fmt.Errorf("source of component %s/%s not yet resolved", m[2], m[3]), new(10*time.Second))
+		}
+		artifact := component.Status.SourceRef.Artifact
+		credentials, err := f.getComponentSourceCredentials(component)
+		if err != nil {
+			return err
+		}
+		cacheDir, release, err := f.acquireArtifact(artifact.Url, artifact.Digest, artifact.Format, credentials)
+		if err != nil {
+			return err
+		}
+		defer release()
+		dir = cacheDir
+		subPath = path.Join(component.Spec.Path, subPath)
+		digest = calculateDigest(artifact.Url, artifact.Digest, artifact.Format, component.Spec.Path)
+	default:
+		panic("this cannot happen")
+	}
+
+	subPath = path.Clean("/" + subPath)[1:]
+	if subPath == "" {
+		subPath = "."
+	}
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+	*externalSources = append(*externalSources, externalSource{url: url, digest: digest})
+	return fn(helmChartSource{fsys: root.FS(), dir: subPath})
+}
+
+// check whether the external sources used by the given generator are still up to date
+func (f *Factory) isUpToDate(generator any) bool {
+	helmGenerator, ok := generator.(*HelmGenerator)
+	if !ok {
+		return true
+	}
+	for _, source := range helmGenerator.externalSources {
+		digest, err := f.getExternalSourceDigest(source.url)
+		if err != nil || digest != source.digest {
+			return false
+		}
+	}
+	return true
+}
+
+// check whether the external sources used by the given generator may be referenced from the given namespace
+func (f *Factory) checkExternalSourceGrants(generator any, namespace string) error {
+	helmGenerator, ok := generator.(*HelmGenerator)
+	if !ok {
+		return nil
+	}
+	for _, source := range helmGenerator.externalSources {
+		m := helmExternalRepositoryPattern.FindStringSubmatch(source.url)
+		if m == nil {
+			return fmt.Errorf("invalid external source: %s", source.url)
+		}
+		if m[2] == namespace {
+			continue
+		}
+		var object client.Object
+		switch m[1] {
+		case "blueprint":
+			object = &operatorv1alpha1.Blueprint{}
+		case "component":
+			object = &operatorv1alpha1.Component{}
+		default:
+			panic("this cannot happen")
+		}
+		if err := f.client.Get(context.TODO(), apitypes.NamespacedName{Namespace: m[2], Name: m[3]}, object); err != nil {
+			return err
+		}
+		if !reference.IsGranted(object, namespace) {
+			return fmt.Errorf("cross-namespace reference to %s/%s (%s) not allowed (not granted by the referenced object)", m[2], m[3], m[1])
+		}
+	}
+	return nil
+}
+
+func (f *Factory) getExternalSourceDigest(url string) (string, error) {
+	m := helmExternalRepositoryPattern.FindStringSubmatch(url)
+	if m == nil {
+		return "", fmt.Errorf("invalid external source: %s", url)
+	}
+	switch m[1] {
+	case "blueprint":
+		blueprint := &operatorv1alpha1.Blueprint{}
+		if err := f.client.Get(context.TODO(), apitypes.NamespacedName{Namespace: m[2], Name: m[3]}, blueprint); err != nil {
+			return "", err
+		}
+		return blueprint.GetDigest(), nil
+	case "component":
+		component := &operatorv1alpha1.Component{}
+		if err := f.client.Get(context.TODO(), apitypes.NamespacedName{Namespace: m[2], Name: m[3]}, component); err != nil {
+			return "", err
+		}
+		if component.Status.SourceRef == nil {
+			return "", nil
+		}
+		artifact := component.Status.SourceRef.Artifact
+		return calculateDigest(artifact.Url, artifact.Digest, artifact.Format, component.Spec.Path), nil
+	default:
+		panic("this cannot happen")
+	}
+}
+
+// read the credentials of the source of the given component (which, other than for the component being reconciled,
+// are not loaded by the framework)
+func (f *Factory) getComponentSourceCredentials(component *operatorv1alpha1.Component) (map[string][]byte, error) {
+	var secretName string
+	switch sourceRef := component.Spec.SourceRef; {
+	case sourceRef.HttpRepository != nil && sourceRef.HttpRepository.SecretRef != nil:
+		secretName = sourceRef.HttpRepository.SecretRef.Name
+	case sourceRef.OciRepository != nil && sourceRef.OciRepository.SecretRef != nil:
+		secretName = sourceRef.OciRepository.SecretRef.Name
+	case sourceRef.GitRepository != nil && sourceRef.GitRepository.SecretRef != nil:
+		secretName = sourceRef.GitRepository.SecretRef.Name
+	default:
+		return nil, nil
+	}
+	secret := &corev1.Secret{}
+	if err := f.client.Get(context.TODO(), apitypes.NamespacedName{Namespace: component.Namespace, Name: secretName}, secret); err != nil {
+		return nil, err
+	}
+	return secret.Data, nil
+}
+
+// dependency of a chart (as declared in Chart.yaml, or implied by the charts directory), located in the in-memory chart tree
+type helmDependency struct {
+	// directory of the subchart
+	dir string
+	// key of the subchart's values within the values of the declaring chart
+	key string
+	// the dependency declaring this dependency; nil if declared by the top-level chart
+	parent    *helmDependency
+	condition string
+	tags      []string
+}
+
+// copy the chart at srcDir (within fsys), including its subcharts, to dstDir (within the in-memory file system of the generator);
+// the dependencies of the chart are recorded (parents before children), and the dependency declarations are removed from Chart.yaml,
+// since they are handled by the generator
+func (g *HelmGenerator) loadChart(fsys fs.FS, srcDir string, dstDir string, alias string, parent *helmDependency, depth int) error {
+	if depth > maxHelmDependencyDepth {
+		return fmt.Errorf("chart dependencies nested too deeply at %s", srcDir)
+	}
+	metadata, err := readHelmChartMetadata(fsys, srcDir)
+	if err != nil {
+		return err
+	}
+	subcharts, _, err := listHelmSubcharts(fsys, srcDir)
+	if err != nil {
+		return err
+	}
+	subchartDirs := make(map[string]bool)
+	for _, dir := range subcharts {
+		subchartDirs[dir] = true
+	}
+
+	if err := fs.WalkDir(fsys, srcDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path.Dir(p) == path.Join(srcDir, "charts") && subchartDirs[d.Name()] {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		rel := strings.TrimPrefix(p, srcDir+"/")
+		if srcDir == "." {
+			rel = p
+		}
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		switch rel {
+		case "Chart.yaml":
+			var chart map[string]any
+			if err := kyaml.Unmarshal(data, &chart); err != nil {
+				return fmt.Errorf("error parsing %s: %w", p, err)
+			}
+			delete(chart, "dependencies")
+			if alias != "" {
+				chart["name"] = alias
+			}
+			data, err = kyaml.Marshal(chart)
+			if err != nil {
+				return err
+			}
+		case "values.yaml":
+			values, err := kyaml.YAMLToJSON(data)
+			if err != nil {
+				return fmt.Errorf("error parsing %s: %w", p, err)
+			}
+			g.chartValues[dstDir] = values
+		}
+		g.fsys.add(path.Join(dstDir, rel), data)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	declared := make(map[string]bool)
+	keys := make(map[string]bool)
+	for _, dependency := range metadata.Dependencies {
+		dir, ok := subcharts[dependency.Name]
+		if !ok {
+			return fmt.Errorf("dependency %s of chart %s not found in charts directory", dependency.Name, metadata.Name)
+		}
+		declared[dependency.Name] = true
+		key := dependency.Name
+		if dependency.Alias != "" {
+			key = dependency.Alias
+		}
+		if keys[key] {
+			return fmt.Errorf("duplicate dependency %s in chart %s", key, metadata.Name)
+		}
+		keys[key] = true
+		d := &helmDependency{
+			dir:       path.Join(dstDir, "charts", key),
+			key:       key,
+			parent:    parent,
+			condition: dependency.Condition,
+			tags:      dependency.Tags,
+		}
+		g.dependencies = append(g.dependencies, d)
+		if err := g.loadChart(fsys, path.Join(srcDir, "charts", dir), d.dir, dependency.Alias, d, depth+1); err != nil {
+			return err
+		}
+	}
+	// note: subcharts which are not declared as dependency are always rendered (but may have conditional dependencies themselves)
+	for _, name := range slices.Sorted(maps.Keys(subcharts)) {
+		if declared[name] {
+			continue
+		}
+		if keys[name] {
+			return fmt.Errorf("duplicate dependency %s in chart %s", name, metadata.Name)
+		}
+		d := &helmDependency{
+			dir:    path.Join(dstDir, "charts", subcharts[name]),
+			key:    name,
+			parent: parent,
+		}
+		g.dependencies = append(g.dependencies, d)
+		if err := g.loadChart(fsys, path.Join(srcDir, "charts", subcharts[name]), d.dir, "", d, depth+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// evaluate conditions and tags of all dependencies against the given values (coalesced with the default values of the
+// charts), and return the directories of the disabled dependencies (in a deterministic order)
+func (g *HelmGenerator) disabledDependencies(values map[string]any) ([]string, error) {
+	topValues, err := coalesceHelmValues(g.chartValues[g.chartPath], values)
+	if err != nil {
+		return nil, err
+	}
+	tags, _ := topValues["tags"].(map[string]any)
+
+	scopes := make(map[*helmDependency]map[string]any)
+	var valuesOf func(d *helmDependency) (map[string]any, error)
+	valuesOf = func(d *helmDependency) (map[string]any, error) {
+		if d == nil {
+			return topValues, nil
+		}
+		if v, ok := scopes[d]; ok {
+			return v, nil
+		}
+		parentValues, err := valuesOf(d.parent)
+		if err != nil {
+			return nil, err
+		}
+		v, _ := parentValues[d.key].(map[string]any)
+		v, err = coalesceHelmValues(g.chartValues[d.dir], v)
+		if err != nil {
+			return nil, err
+		}
+		scopes[d] = v
+		return v, nil
+	}
+
+	// note: values of subcharts are coalesced with the default values of the subchart, so conditions referring to a subchart
+	// (such as <subchart>.enabled) have to be resolved within the values of that subchart
+	children := make(map[*helmDependency]map[string]*helmDependency)
+	for _, d := range g.dependencies {
+		if children[d.parent] == nil {
+			children[d.parent] = make(map[string]*helmDependency)
+		}
+		children[d.parent][d.key] = d
+	}
+	var lookup func(scope *helmDependency, keys []string) (any, error)
+	lookup = func(scope *helmDependency, keys []string) (any, error) {
+		if child, ok := children[scope][keys[0]]; ok && len(keys) > 1 {
+			return lookup(child, keys[1:])
+		}
+		v, err := valuesOf(scope)
+		if err != nil {
+			return nil, err
+		}
+		return lookupValue(v, keys), nil
+	}
+
+	disabled := make(map[*helmDependency]bool)
+	var result []string
+	for _, d := range g.dependencies {
+		if d.parent != nil && disabled[d.parent] {
+			// note: the parent's directory is already hidden, including this dependency
+			disabled[d] = true
+			continue
+		}
+		enabled, err := isHelmDependencyEnabled(d, func(keys []string) (any, error) { return lookup(d.parent, keys) }, tags)
+		if err != nil {
+			return nil, err
+		}
+		if !enabled {
+			disabled[d] = true
+			result = append(result, d.dir)
+		}
+	}
+	return result, nil
+}
+
+// evaluate condition and tags of a dependency, following the semantics of helm: the first condition path resolving to a boolean
+// decides; otherwise, the dependency is enabled if one of its tags is true, or if none of its tags is false
+func isHelmDependencyEnabled(d *helmDependency, lookup func(keys []string) (any, error), tags map[string]any) (bool, error) {
+	for _, condition := range strings.Split(d.condition, ",") {
+		condition = strings.TrimSpace(condition)
+		if condition == "" {
+			continue
+		}
+		value, err := lookup(strings.Split(condition, "."))
+		if err != nil {
+			return false, err
+		}
+		if enabled, ok := value.(bool); ok {
+			return enabled, nil
+		}
+	}
+	hasTrue := false
+	hasFalse := false
+	for _, tag := range d.tags {
+		if enabled, ok := tags[tag].(bool); ok {
+			if enabled {
+				hasTrue = true
+			} else {
+				hasFalse = true
+			}
+		}
+	}
+	return hasTrue || !hasFalse, nil
+}
+
+// coalesce the given values with the given default values (in JSON format); the passed values are not modified
+func coalesceHelmValues(defaultValues []byte, values map[string]any) (map[string]any, error) {
+	var result map[string]any
+	if defaultValues != nil {
+		if err := json.Unmarshal(defaultValues, &result); err != nil {
+			return nil, err
+		}
+	}
+	if result == nil {
+		result = make(map[string]any)
+	}
+	var v map[string]any
+	if err := copyJSON(values, &v); err != nil {
+		return nil, err
+	}
+	deepMerge(result, v)
+	return result, nil
+}
+
+func lookupValue(values map[string]any, keys []string) any {
+	var value any = values
+	for _, key := range keys {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = m[key]
+	}
+	return value
+}
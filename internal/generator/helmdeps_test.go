@@ -0,0 +1,478 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package generator
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/sap/component-operator/internal/testutil"
+)
+
+// write the given files (and symbolic links, mapped to their targets) below dir
+func writeTestTree(t *testing.T, dir string, files map[string]string, links map[string]string) {
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, target := range links {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(filepath.FromSlash(target), p); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// return a gzipped chart archive containing the given files below a single top-level directory
+func newTestChartArchive(t *testing.T, dir string, files map[string]string) string {
+	buf := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, content := range files {
+		if err := tarWriter.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: dir + "/" + name, Mode: 0644, Size: int64(len(content))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tarWriter.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func testChartYaml(name string, dependencies ...string) string {
+	s := fmt.Sprintf("apiVersion: v2\nname: %s\nversion: 1.0.0\n", name)
+	if len(dependencies) > 0 {
+		s += "dependencies:\n" + strings.Join(dependencies, "\n") + "\n"
+	}
+	return s
+}
+
+func TestVendorHelmDependencies(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         map[string]string
+		links         map[string]string
+		expectedFiles map[string]string
+		expectedErr   string
+	}{
+		{
+			name: "file repository",
+			files: map[string]string{
+				"app/Chart.yaml": testChartYaml("app", "- name: lib\n  repository: file://../lib"),
+				"lib/Chart.yaml": testChartYaml("lib"),
+			},
+			expectedFiles: map[string]string{"app/charts/lib/Chart.yaml": testChartYaml("lib")},
+		},
+		{
+			name: "relative repository",
+			files: map[string]string{
+				"app/Chart.yaml": testChartYaml("app", "- name: lib\n  repository: ../lib"),
+				"lib/Chart.yaml": testChartYaml("lib"),
+			},
+			expectedFiles: map[string]string{"app/charts/lib/Chart.yaml": testChartYaml("lib")},
+		},
+		{
+			name: "relative repository of dependency resolved within its source",
+			files: map[string]string{
+				"app/Chart.yaml":        testChartYaml("app", "- name: lib\n  repository: file://../libs/lib"),
+				"libs/lib/Chart.yaml":   testChartYaml("lib", "- name: base\n  repository: file://../base"),
+				"libs/base/Chart.yaml":  testChartYaml("base"),
+				"libs/base/values.yaml": "key: value\n",
+			},
+			expectedFiles: map[string]string{"app/charts/lib/charts/base/values.yaml": "key: value\n"},
+		},
+		{
+			name: "repository path escaping artifact",
+			files: map[string]string{
+				"app/Chart.yaml": testChartYaml("app", "- name: lib\n  repository: file://../../lib"),
+			},
+			expectedErr: "path points outside of the artifact",
+		},
+		{
+			name: "absolute repository path",
+			files: map[string]string{
+				"app/Chart.yaml": testChartYaml("app", "- name: lib\n  repository: file:///lib"),
+			},
+			expectedErr: "absolute paths are not allowed",
+		},
+		{
+			name: "repository with chart name mismatch",
+			files: map[string]string{
+				"app/Chart.yaml": testChartYaml("app", "- name: lib\n  repository: file://../lib"),
+				"lib/Chart.yaml": testChartYaml("other"),
+			},
+			expectedErr: "has name other (expected lib)",
+		},
+		{
+			name: "unsupported repository",
+			files: map[string]string{
+				"app/Chart.yaml": testChartYaml("app", "- name: lib\n  repository: https://charts.example.com"),
+			},
+			expectedErr: "unsupported repository for dependency lib",
+		},
+		{
+			name: "missing repository",
+			files: map[string]string{
+				"app/Chart.yaml": testChartYaml("app", "- name: lib"),
+			},
+			expectedErr: "neither contained in the charts directory, nor does it specify a repository",
+		},
+		{
+			name: "circular dependencies",
+			files: map[string]string{
+				"app/Chart.yaml": testChartYaml("app", "- name: lib\n  repository: file://../lib"),
+				"lib/Chart.yaml": testChartYaml("lib", "- name: app\n  repository: file://../app"),
+			},
+			expectedErr: "chart dependencies nested too deeply",
+		},
+		{
+			name: "chart archive",
+			files: map[string]string{
+				"app/Chart.yaml":           testChartYaml("app", "- name: lib\n  version: 1.0.0"),
+				"app/charts/lib-1.0.0.tgz": newTestChartArchive(t, "lib", map[string]string{"Chart.yaml": testChartYaml("lib"), "values.yaml": "key: value\n"}),
+			},
+			expectedFiles: map[string]string{"app/charts/lib/values.yaml": "key: value\n"},
+		},
+		{
+			name: "chart archive with name mismatch",
+			files: map[string]string{
+				"app/Chart.yaml":           testChartYaml("app", "- name: lib\n  version: 1.0.0"),
+				"app/charts/lib-1.0.0.tgz": newTestChartArchive(t, "lib", map[string]string{"Chart.yaml": testChartYaml("other")}),
+			},
+			expectedErr: "has name other (expected lib)",
+		},
+		{
+			name: "chart archive with multiple top-level entries",
+			files: map[string]string{
+				"app/Chart.yaml":           testChartYaml("app", "- name: lib\n  version: 1.0.0"),
+				"app/charts/lib-1.0.0.tgz": newTestChartArchive(t, ".", map[string]string{"Chart.yaml": testChartYaml("lib"), "values.yaml": ""}),
+			},
+			expectedErr: "expected exactly one top-level directory",
+		},
+		{
+			name: "symbolic links within artifact",
+			files: map[string]string{
+				"app/Chart.yaml":       testChartYaml("app", "- name: lib\n  repository: file://../lib"),
+				"lib/Chart.yaml":       testChartYaml("lib"),
+				"common/_helpers.tpl":  "helpers",
+				"common/crds/crd.yaml": "crd",
+			},
+			links: map[string]string{
+				"app/templates/_helpers.tpl": "../../common/_helpers.tpl",
+				"lib/templates/_helpers.tpl": "../../common/_helpers.tpl",
+				"lib/crds":                   "../common/crds",
+			},
+			expectedFiles: map[string]string{
+				"app/templates/_helpers.tpl":            "helpers",
+				"app/charts/lib/templates/_helpers.tpl": "helpers",
+				"app/charts/lib/crds/crd.yaml":          "crd",
+			},
+		},
+		{
+			name: "symbolic link escaping artifact",
+			files: map[string]string{
+				"app/Chart.yaml": testChartYaml("app", "- name: lib\n  repository: file://../lib"),
+				"lib/Chart.yaml": testChartYaml("lib"),
+			},
+			links: map[string]string{
+				"lib/templates/_helpers.tpl": "../../../outside/_helpers.tpl",
+			},
+			expectedErr: "cannot be resolved within the source",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			artifactPath := filepath.Join(t.TempDir(), "artifact")
+			writeTestTree(t, artifactPath, test.files, test.links)
+			// note: placed next to the artifact, such that links escaping the artifact would resolve
+			writeTestTree(t, filepath.Dir(artifactPath), map[string]string{"outside/_helpers.tpl": "outside"}, nil)
+			artifactRoot, err := os.OpenRoot(artifactPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer artifactRoot.Close()
+
+			// note: the chart is copied before vendoring (like the factory does), since vendoring happens in place
+			workPath := t.TempDir()
+			if err := copyDirectory(artifactRoot.FS(), "app", filepath.Join(workPath, "app")); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			workRoot, err := os.OpenRoot(workPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer workRoot.Close()
+
+			var externalSources []externalSource
+			err = newTestFactory(t).vendorHelmDependencies(workRoot, "app", helmChartSource{fsys: artifactRoot.FS(), dir: "app"}, 0, &externalSources)
+			if testutil.CheckError(t, err, test.expectedErr) {
+				return
+			}
+			for name, expectedContent := range test.expectedFiles {
+				info, err := os.Lstat(filepath.Join(workPath, filepath.FromSlash(name)))
+				if err != nil {
+					t.Fatalf("expected file %s: %s", name, err)
+				}
+				if !info.Mode().IsRegular() {
+					t.Errorf("expected %s to be a regular file, got mode %s", name, info.Mode())
+				}
+				content, err := os.ReadFile(filepath.Join(workPath, filepath.FromSlash(name)))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(content) != expectedContent {
+					t.Errorf("file %s: expected content %q, got %q", name, expectedContent, content)
+				}
+			}
+			if archives, _ := filepath.Glob(filepath.Join(workPath, "app", "charts", "*.tgz")); len(archives) > 0 {
+				t.Errorf("expected extracted chart archives to be removed, got %v", archives)
+			}
+			if len(externalSources) > 0 {
+				t.Errorf("expected no external sources, got %v", externalSources)
+			}
+		})
+	}
+}
+
+// load the chart contained in the given files (as the helm generator does for charts with dependencies)
+func newTestHelmGenerator(files map[string]string) (*HelmGenerator, error) {
+	fsys := fstest.MapFS{}
+	for name, content := range files {
+		fsys[name] = &fstest.MapFile{Data: []byte(content)}
+	}
+	g := &HelmGenerator{
+		fsys:        newMemFS(),
+		chartPath:   ".",
+		chartValues: make(map[string][]byte),
+	}
+	if err := g.loadChart(fsys, ".", ".", "", nil, 0); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func TestLoadChart(t *testing.T) {
+	// note: charts nested more deeply than allowed
+	deepFiles := map[string]string{}
+	dir := ""
+	for i := 0; i <= maxHelmDependencyDepth+1; i++ {
+		name := fmt.Sprintf("chart%d", i)
+		deepFiles[dir+"Chart.yaml"] = testChartYaml(name)
+		dir += "charts/" + name + "/"
+	}
+
+	tests := []struct {
+		name         string
+		files        map[string]string
+		expectedDirs []string
+		expectedErr  string
+	}{
+		{
+			name: "declared and undeclared subcharts",
+			files: map[string]string{
+				"Chart.yaml":                         testChartYaml("app", "- name: sub\n  alias: first", "- name: sub\n  alias: second"),
+				"charts/sub/Chart.yaml":              testChartYaml("sub"),
+				"charts/sub/charts/inner/Chart.yaml": testChartYaml("inner"),
+				"charts/plain/Chart.yaml":            testChartYaml("plain"),
+			},
+			expectedDirs: []string{"charts/first", "charts/first/charts/inner", "charts/second", "charts/second/charts/inner", "charts/plain"},
+		},
+		{
+			name: "duplicate alias",
+			files: map[string]string{
+				"Chart.yaml":            testChartYaml("app", "- name: sub\n  alias: other", "- name: sub\n  alias: other"),
+				"charts/sub/Chart.yaml": testChartYaml("sub"),
+			},
+			expectedErr: "duplicate dependency other in chart app",
+		},
+		{
+			name: "alias conflicting with undeclared subchart",
+			files: map[string]string{
+				"Chart.yaml":              testChartYaml("app", "- name: sub\n  alias: plain"),
+				"charts/sub/Chart.yaml":   testChartYaml("sub"),
+				"charts/plain/Chart.yaml": testChartYaml("plain"),
+			},
+			expectedErr: "duplicate dependency plain in chart app",
+		},
+		{
+			name: "nested duplicate alias",
+			files: map[string]string{
+				"Chart.yaml":                         testChartYaml("app", "- name: sub"),
+				"charts/sub/Chart.yaml":              testChartYaml("sub", "- name: inner\n  alias: x", "- name: inner\n  alias: x"),
+				"charts/sub/charts/inner/Chart.yaml": testChartYaml("inner"),
+			},
+			expectedErr: "duplicate dependency x in chart sub",
+		},
+		{
+			name: "invalid alias",
+			files: map[string]string{
+				"Chart.yaml":            testChartYaml("app", "- name: sub\n  alias: ../sub"),
+				"charts/sub/Chart.yaml": testChartYaml("sub"),
+			},
+			expectedErr: "invalid dependency name or alias",
+		},
+		{
+			name: "missing dependency",
+			files: map[string]string{
+				"Chart.yaml": testChartYaml("app", "- name: sub"),
+			},
+			expectedErr: "dependency sub of chart app not found in charts directory",
+		},
+		{
+			name:        "nested too deeply",
+			files:       deepFiles,
+			expectedErr: "chart dependencies nested too deeply",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g, err := newTestHelmGenerator(test.files)
+			if testutil.CheckError(t, err, test.expectedErr) {
+				return
+			}
+			var dirs []string
+			for _, d := range g.dependencies {
+				dirs = append(dirs, d.dir)
+			}
+			if !reflect.DeepEqual(dirs, test.expectedDirs) {
+				t.Errorf("expected dependency directories %v, got %v", test.expectedDirs, dirs)
+			}
+		})
+	}
+}
+
+func TestDisabledDependencies(t *testing.T) {
+	g, err := newTestHelmGenerator(map[string]string{
+		"Chart.yaml": testChartYaml("app",
+			"- name: sub\n  condition: sub.enabled",
+			"- name: sub\n  alias: other\n  condition: other.missing,other.enabled\n  tags: [backend]",
+			"- name: tagged\n  tags: [frontend, backend]",
+		),
+		"values.yaml":                           "",
+		"charts/sub/Chart.yaml":                 testChartYaml("sub", "- name: nested\n  condition: nested.enabled"),
+		"charts/sub/values.yaml":                "enabled: true\nnested:\n  enabled: false\n",
+		"charts/sub/charts/nested/Chart.yaml":   testChartYaml("nested"),
+		"charts/tagged/Chart.yaml":              testChartYaml("tagged"),
+		"charts/plain/Chart.yaml":               testChartYaml("plain", "- name: inner\n  condition: inner.enabled"),
+		"charts/plain/charts/inner/Chart.yaml":  testChartYaml("inner"),
+		"charts/plain/charts/inner/values.yaml": "enabled: false\n",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	const (
+		sub         = "charts/sub"
+		subNested   = "charts/sub/charts/nested"
+		other       = "charts/other"
+		otherNested = "charts/other/charts/nested"
+		tagged      = "charts/tagged"
+		plainInner  = "charts/plain/charts/inner"
+	)
+
+	tests := []struct {
+		name     string
+		values   string
+		expected []string
+	}{
+		{
+			name:     "defaults of subcharts",
+			values:   `{}`,
+			expected: []string{subNested, otherNested, plainInner},
+		},
+		{
+			name:     "condition resolved in values of declaring chart",
+			values:   `{"sub": {"nested": {"enabled": true}}, "plain": {"inner": {"enabled": true}}}`,
+			expected: []string{otherNested},
+		},
+		{
+			name:     "condition not resolved in top-level values",
+			values:   `{"nested": {"enabled": true}, "inner": {"enabled": true}}`,
+			expected: []string{subNested, otherNested, plainInner},
+		},
+		{
+			name:     "disabled parent hides children",
+			values:   `{"sub": {"enabled": false, "nested": {"enabled": true}}}`,
+			expected: []string{sub, otherNested, plainInner},
+		},
+		{
+			name:     "condition of alias resolved by alias",
+			values:   `{"sub": {"enabled": false}, "other": {"nested": {"enabled": true}}}`,
+			expected: []string{sub, plainInner},
+		},
+		{
+			name:     "first boolean condition wins",
+			values:   `{"other": {"missing": false, "enabled": true}}`,
+			expected: []string{subNested, other, plainInner},
+		},
+		{
+			name:     "non-boolean condition skipped",
+			values:   `{"other": {"missing": "yes", "enabled": false}}`,
+			expected: []string{subNested, other, plainInner},
+		},
+		{
+			name:     "condition wins over tags",
+			values:   `{"tags": {"backend": false}}`,
+			expected: []string{subNested, otherNested, tagged, plainInner},
+		},
+		{
+			name:     "tags apply if no condition resolves to a boolean",
+			values:   `{"tags": {"backend": false}, "other": {"enabled": "no"}}`,
+			expected: []string{subNested, other, tagged, plainInner},
+		},
+		{
+			name:     "true tag wins over false tag",
+			values:   `{"tags": {"frontend": true, "backend": false}}`,
+			expected: []string{subNested, otherNested, plainInner},
+		},
+		{
+			name:     "non-boolean tags ignored",
+			values:   `{"tags": {"frontend": "false", "backend": null}}`,
+			expected: []string{subNested, otherNested, plainInner},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var values map[string]any
+			if err := json.Unmarshal([]byte(test.values), &values); err != nil {
+				t.Fatal(err)
+			}
+			disabled, err := g.disabledDependencies(values)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(disabled, test.expected) {
+				t.Errorf("expected disabled dependencies %v, got %v", test.expected, disabled)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+
+	"github.com/google/go-jsonnet"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/sap/component-operator-runtime/pkg/manifests"
+	componentoperatorruntimetypes "github.com/sap/component-operator-runtime/pkg/types"
+)
+
+const (
+	jsonnetValuesModeTLA    = "tla"
+	jsonnetValuesModeExtVar = "extVar"
+)
+
+// JsonnetGenerator evaluates a Jsonnet main file; the values are passed either as top-level argument,
+// or as external variable (with a configurable name). Imports are resolved relative to the importing file first,
+// and then relative to the artifact root; imports outside of the artifact root are not possible.
+// The result of the evaluation may be a single object (or a list of kind List), an array, or an object whose fields
+// contain objects (recursively); null values are skipped.
+type JsonnetGenerator struct {
+	mainFile   string
+	valuesMode string
+	valuesName string
+	importer   *jsonnetImporter
+}
+
+var _ manifests.Generator = &JsonnetGenerator{}
+
+// Create a new JsonnetGenerator for the given main file (relative to the root of fsys). The main file and all files
+// (transitively) imported by it are read upon creation; fsys is not accessed afterwards. The given decryptor
+// (which may be nil) is applied to the content of all files.
+func NewJsonnetGenerator(fsys fs.FS, mainFile string, valuesMode string, valuesName string, decryptor manifests.Decryptor) (*JsonnetGenerator, error) {
+	switch valuesMode {
+	case "":
+		valuesMode = jsonnetValuesModeTLA
+	case jsonnetValuesModeTLA, jsonnetValuesModeExtVar:
+	default:
+		return nil, fmt.Errorf("invalid jsonnet values mode: %s", valuesMode)
+	}
+	if valuesName == "" {
+		valuesName = "values"
+	}
+	mainFile = path.Clean(mainFile)
+	if !fs.ValidPath(mainFile) || mainFile == "." {
+		return nil, fmt.Errorf("invalid jsonnet main file: %s", mainFile)
+	}
+
+	g := &JsonnetGenerator{
+		mainFile:   mainFile,
+		valuesMode: valuesMode,
+		valuesName: valuesName,
+		importer: &jsonnetImporter{
+			fsys:      fsys,
+			decryptor: decryptor,
+			files:     make(map[string]jsonnet.Contents),
+		},
+	}
+
+	// note: imports in jsonnet are static, so all files needed for the evaluation can be determined upfront
+	vm := jsonnet.MakeVM()
+	vm.Importer(g.importer)
+	if _, err := vm.FindDependencies("", []string{mainFile}); err != nil {
+		return nil, err
+	}
+	g.importer.fsys = nil
+
+	return g, nil
+}
+
+// Implement the manifests.Generator interface.
+func (g *JsonnetGenerator) Generate(ctx context.Context, namespace string, name string, parameters componentoperatorruntimetypes.Unstructurable) ([]client.Object, error) {
+	values, err := json.Marshal(parameters.ToUnstructured())
+	if err != nil {
+		return nil, err
+	}
+
+	// note: vms are not safe for concurrent use, so a new one is created for each evaluation
+	vm := jsonnet.MakeVM()
+	vm.Importer(g.importer)
+	switch g.valuesMode {
+	case jsonnetValuesModeTLA:
+		vm.TLACode(g.valuesName, string(values))
+	case jsonnetValuesModeExtVar:
+		vm.ExtCode(g.valuesName, string(values))
+	}
+	output, err := vm.EvaluateFile(g.mainFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var result any
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		return nil, err
+	}
+	var objects []client.Object
+	if err := collectObjects(result, &objects); err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// importer serving files from fsys (as long as it is set), and from the files read so far; note that the jsonnet vm
+// requires that the same Contents instance is returned for repeated imports of the same file
+type jsonnetImporter struct {
+	fsys      fs.FS
+	decryptor manifests.Decryptor
+	files     map[string]jsonnet.Contents
+}
+
+var _ jsonnet.Importer = &jsonnetImporter{}
+
+func (i *jsonnetImporter) Import(importedFrom string, importedPath string) (jsonnet.Contents, string, error) {
+	if path.IsAbs(importedPath) {
+		return jsonnet.Contents{}, "", fmt.Errorf("absolute import paths are not allowed: %s", importedPath)
+	}
+	// note: candidates are the path relative to the importing file, and the path relative to the artifact root
+	candidates := []string{path.Join(path.Dir(importedFrom), importedPath)}
+	if importedFrom != "" {
+		candidates = append(candidates, path.Clean(importedPath))
+	}
+	for _, candidate := range candidates {
+		if !fs.ValidPath(candidate) || candidate == "." {
+			return jsonnet.Contents{}, "", fmt.Errorf("import points outside of the artifact root: %s", importedPath)
+		}
+		contents, err := i.read(candidate)
+		if err == nil {
+			return contents, candidate, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return jsonnet.Contents{}, "", err
+		}
+	}
+	return jsonnet.Contents{}, "", fmt.Errorf("import not found: %s", importedPath)
+}
+
+func (i *jsonnetImporter) read(name string) (jsonnet.Contents, error) {
+	if contents, ok := i.files[name]; ok {
+		return contents, nil
+	}
+	if i.fsys == nil {
+		return jsonnet.Contents{}, fs.ErrNotExist
+	}
+	data, err := fs.ReadFile(i.fsys, name)
+	if err != nil {
+		return jsonnet.Contents{}, err
+	}
+	if i.decryptor != nil {
+		data, err = i.decryptor.Decrypt(data, name)
+		if err != nil {
+			return jsonnet.Contents{}, err
+		}
+	}
+	contents := jsonnet.MakeContentsRaw(data)
+	i.files[name] = contents
+	return contents, nil
+}
@@ -0,0 +1,175 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package generator
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// read-only in-memory file system; directories are implied by the contained files
+type memFS struct {
+	files map[string][]byte
+}
+
+var _ fs.ReadDirFS = &memFS{}
+var _ fs.ReadFileFS = &memFS{}
+
+func newMemFS() *memFS {
+	return &memFS{files: make(map[string][]byte)}
+}
+
+func (m *memFS) add(name string, data []byte) {
+	m.files[path.Clean(name)] = data
+}
+
+func (m *memFS) size() int64 {
+	var size int64
+	for _, data := range m.files {
+		size += int64(len(data))
+	}
+	return size
+}
+
+func (m *memFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if data, ok := m.files[name]; ok {
+		return &memFile{name: name, reader: bytes.NewReader(data), size: int64(len(data))}, nil
+	}
+	entries, ok := m.readDir(name)
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return &memDir{name: name, entries: entries}, nil
+}
+
+func (m *memFS) ReadFile(name string) ([]byte, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: fs.ErrInvalid}
+	}
+	data, ok := m.files[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: fs.ErrNotExist}
+	}
+	return slices.Clone(data), nil
+}
+
+func (m *memFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	entries, ok := m.readDir(name)
+	if !ok {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	return entries, nil
+}
+
+// return the (sorted) entries of the given directory, and whether the directory exists
+func (m *memFS) readDir(name string) ([]fs.DirEntry, bool) {
+	prefix := ""
+	if name != "." {
+		prefix = name + "/"
+	}
+	found := name == "."
+	entries := make(map[string]fs.DirEntry)
+	for file, data := range m.files {
+		if !strings.HasPrefix(file, prefix) {
+			continue
+		}
+		found = true
+		rest := strings.TrimPrefix(file, prefix)
+		if i := strings.IndexByte(rest, '/'); i >= 0 {
+			entries[rest[:i]] = fs.FileInfoToDirEntry(&memFileInfo{name: rest[:i], dir: true})
+		} else {
+			entries[rest] = fs.FileInfoToDirEntry(&memFileInfo{name: rest, size: int64(len(data))})
+		}
+	}
+	if !found {
+		return nil, false
+	}
+	result := make([]fs.DirEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, entry)
+	}
+	slices.SortFunc(result, func(x, y fs.DirEntry) int { return strings.Compare(x.Name(), y.Name()) })
+	return result, true
+}
+
+type memFile struct {
+	name   string
+	reader *bytes.Reader
+	size   int64
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) {
+	return &memFileInfo{name: path.Base(f.name), size: f.size}, nil
+}
+
+func (f *memFile) Read(p []byte) (int, error) {
+	return f.reader.Read(p)
+}
+
+func (f *memFile) Close() error {
+	return nil
+}
+
+type memDir struct {
+	name    string
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *memDir) Stat() (fs.FileInfo, error) {
+	return &memFileInfo{name: path.Base(d.name), dir: true}, nil
+}
+
+func (d *memDir) Read(p []byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: fs.ErrInvalid}
+}
+
+func (d *memDir) Close() error {
+	return nil
+}
+
+func (d *memDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	remaining := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return remaining, nil
+	}
+	if len(remaining) == 0 {
+		return nil, io.EOF
+	}
+	n = min(n, len(remaining))
+	d.offset += n
+	return remaining[:n], nil
+}
+
+type memFileInfo struct {
+	name string
+	size int64
+	dir  bool
+}
+
+func (i *memFileInfo) Name() string { return i.name }
+func (i *memFileInfo) Size() int64  { return i.size }
+func (i *memFileInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0755
+	}
+	return 0644
+}
+func (i *memFileInfo) ModTime() time.Time { return time.Time{} }
+func (i *memFileInfo) IsDir() bool        { return i.dir }
+func (i *memFileInfo) Sys() any           { return nil }
@@ -0,0 +1,144 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package generator
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	operatorv1alpha1 "github.com/sap/component-operator/api/v1alpha1"
+)
+
+const defaultListMergeKey = "name"
+
+type listMergeSettings struct {
+	strategy operatorv1alpha1.ListMergeStrategy
+	mergeKey string
+}
+
+// merger for (json-like) values; maps are merged recursively, scalars are replaced, and lists are handled according
+// to the configured strategies; the zero value replaces lists
+type valuesMerger struct {
+	lists listMergeSettings
+	// note: keyed by the path of the list (map keys joined by newlines)
+	paths map[string]listMergeSettings
+}
+
+// create a merger from the given strategies (which may be nil); later strategies take precedence over earlier ones
+func newValuesMerger(strategies ...*operatorv1alpha1.ValuesMergeStrategy) (*valuesMerger, error) {
+	m := &valuesMerger{
+		lists: listMergeSettings{
+			strategy: operatorv1alpha1.ListMergeStrategyReplace,
+			mergeKey: defaultListMergeKey,
+		},
+		paths: make(map[string]listMergeSettings),
+	}
+	for _, strategy := range strategies {
+		if strategy == nil {
+			continue
+		}
+		mergeKey := strategy.MergeKey
+		if mergeKey == "" {
+			mergeKey = defaultListMergeKey
+		}
+		if strategy.Lists != "" {
+			m.lists = listMergeSettings{strategy: strategy.Lists, mergeKey: mergeKey}
+		} else if strategy.MergeKey != "" {
+			m.lists.mergeKey = mergeKey
+		}
+		for _, pathStrategy := range strategy.Paths {
+			p, err := parseValuePath(pathStrategy.Path)
+			if err != nil {
+				return nil, err
+			}
+			var keys []string
+			for _, segment := range p {
+				key, ok := segment.(string)
+				if !ok {
+					return nil, fmt.Errorf("invalid merge strategy path %s: list indices are not allowed", pathStrategy.Path)
+				}
+				keys = append(keys, key)
+			}
+			settings := listMergeSettings{strategy: pathStrategy.Strategy, mergeKey: pathStrategy.MergeKey}
+			if settings.mergeKey == "" {
+				settings.mergeKey = mergeKey
+			}
+			m.paths[strings.Join(keys, "\n")] = settings
+		}
+	}
+	return m, nil
+}
+
+// merge y into x; note that x is modified, and that (parts of) y might become part of x
+func (m *valuesMerger) merge(x map[string]any, y map[string]any) {
+	m.mergeMaps(x, y, nil)
+}
+
+func (m *valuesMerger) mergeMaps(x map[string]any, y map[string]any, path []string) {
+	for k, w := range y {
+		p := append(path[:len(path):len(path)], k)
+		v, ok := x[k]
+		if !ok {
+			x[k] = w
+			continue
+		}
+		switch w := w.(type) {
+		case map[string]any:
+			if v, ok := v.(map[string]any); ok {
+				m.mergeMaps(v, w, p)
+				continue
+			}
+		case []any:
+			if v, ok := v.([]any); ok {
+				x[k] = m.mergeLists(v, w, p)
+				continue
+			}
+		}
+		x[k] = w
+	}
+}
+
+func (m *valuesMerger) mergeLists(x []any, y []any, path []string) []any {
+	settings, ok := m.paths[strings.Join(path, "\n")]
+	if !ok {
+		settings = m.lists
+	}
+
+	switch settings.strategy {
+	case operatorv1alpha1.ListMergeStrategyAppend:
+		return append(slices.Clone(x), y...)
+	case operatorv1alpha1.ListMergeStrategyMergeByKey:
+		result := slices.Clone(x)
+		for _, item := range y {
+			if i := findListItem(result, settings.mergeKey, item); i >= 0 {
+				// note: list items do not contribute to the path, so nested lists are matched by the path of the enclosing list
+				m.mergeMaps(result[i].(map[string]any), item.(map[string]any), path)
+			} else {
+				result = append(result, item)
+			}
+		}
+		return result
+	default:
+		return y
+	}
+}
+
+// find the index of the map in list whose value at key equals the according value of item; returns -1 if there is
+// no such map, or if item is not a map, or has no (or a null) value at key
+func findListItem(list []any, key string, item any) int {
+	itemMap, ok := item.(map[string]any)
+	if !ok || itemMap[key] == nil {
+		return -1
+	}
+	for i, other := range list {
+		if otherMap, ok := other.(map[string]any); ok && reflect.DeepEqual(otherMap[key], itemMap[key]) {
+			return i
+		}
+	}
+	return -1
+}
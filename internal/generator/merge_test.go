@@ -0,0 +1,239 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package generator
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	operatorv1alpha1 "github.com/sap/component-operator/api/v1alpha1"
+)
+
+func parseTestValues(t *testing.T, s string) map[string]any {
+	t.Helper()
+	var v map[string]any
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatalf("invalid test values %s: %s", s, err)
+	}
+	return v
+}
+
+func TestValuesMerger(t *testing.T) {
+	replace := &operatorv1alpha1.ValuesMergeStrategy{Lists: operatorv1alpha1.ListMergeStrategyReplace}
+	appendLists := &operatorv1alpha1.ValuesMergeStrategy{Lists: operatorv1alpha1.ListMergeStrategyAppend}
+	mergeByKey := &operatorv1alpha1.ValuesMergeStrategy{Lists: operatorv1alpha1.ListMergeStrategyMergeByKey}
+
+	tests := []struct {
+		name       string
+		strategies []*operatorv1alpha1.ValuesMergeStrategy
+		x          string
+		y          string
+		expected   string
+	}{
+		{
+			name:     "missing keys are added",
+			x:        `{"a":1}`,
+			y:        `{"b":{"c":2}}`,
+			expected: `{"a":1,"b":{"c":2}}`,
+		},
+		{
+			name:     "maps are merged recursively",
+			x:        `{"a":{"b":1,"c":{"d":2}}}`,
+			y:        `{"a":{"c":{"e":3}}}`,
+			expected: `{"a":{"b":1,"c":{"d":2,"e":3}}}`,
+		},
+		{
+			name:     "scalars are replaced",
+			x:        `{"a":1,"b":"x"}`,
+			y:        `{"a":2,"b":null}`,
+			expected: `{"a":2,"b":null}`,
+		},
+		{
+			name:     "lists are replaced by default",
+			x:        `{"a":[1,2]}`,
+			y:        `{"a":[3]}`,
+			expected: `{"a":[3]}`,
+		},
+		{
+			name:       "replace",
+			strategies: []*operatorv1alpha1.ValuesMergeStrategy{replace},
+			x:          `{"a":[1,2]}`,
+			y:          `{"a":[3]}`,
+			expected:   `{"a":[3]}`,
+		},
+		{
+			name:       "append",
+			strategies: []*operatorv1alpha1.ValuesMergeStrategy{appendLists},
+			x:          `{"a":[1,2],"b":{"c":[{"name":"x"}]}}`,
+			y:          `{"a":[2,3],"b":{"c":[{"name":"x"}]}}`,
+			expected:   `{"a":[1,2,2,3],"b":{"c":[{"name":"x"},{"name":"x"}]}}`,
+		},
+		{
+			name:       "merge by key",
+			strategies: []*operatorv1alpha1.ValuesMergeStrategy{mergeByKey},
+			x:          `{"env":[{"name":"A","value":"1"},{"name":"B","value":"2"}]}`,
+			y:          `{"env":[{"name":"B","value":"3","extra":true},{"name":"C","value":"4"}]}`,
+			expected:   `{"env":[{"name":"A","value":"1"},{"name":"B","value":"3","extra":true},{"name":"C","value":"4"}]}`,
+		},
+		{
+			name:       "merge by key merges items recursively",
+			strategies: []*operatorv1alpha1.ValuesMergeStrategy{mergeByKey},
+			x:          `{"containers":[{"name":"a","resources":{"limits":{"cpu":"1"}},"ports":[{"name":"http","port":80}]}]}`,
+			y:          `{"containers":[{"name":"a","resources":{"limits":{"memory":"1Gi"}},"ports":[{"name":"http","port":8080},{"name":"grpc","port":9090}]}]}`,
+			expected:   `{"containers":[{"name":"a","resources":{"limits":{"cpu":"1","memory":"1Gi"}},"ports":[{"name":"http","port":8080},{"name":"grpc","port":9090}]}]}`,
+		},
+		{
+			name:       "merge by key with items missing the key",
+			strategies: []*operatorv1alpha1.ValuesMergeStrategy{mergeByKey},
+			x:          `{"a":[{"value":1},{"name":null,"value":2}]}`,
+			y:          `{"a":[{"value":3},{"name":null,"value":4}]}`,
+			expected:   `{"a":[{"value":1},{"name":null,"value":2},{"value":3},{"name":null,"value":4}]}`,
+		},
+		{
+			name:       "merge by key with duplicate keys in target",
+			strategies: []*operatorv1alpha1.ValuesMergeStrategy{mergeByKey},
+			x:          `{"a":[{"name":"x","value":1},{"name":"x","value":2}]}`,
+			y:          `{"a":[{"name":"x","value":3}]}`,
+			expected:   `{"a":[{"name":"x","value":3},{"name":"x","value":2}]}`,
+		},
+		{
+			name:       "merge by key with duplicate keys in source",
+			strategies: []*operatorv1alpha1.ValuesMergeStrategy{mergeByKey},
+			x:          `{"a":[{"name":"x","value":1}]}`,
+			y:          `{"a":[{"name":"x","value":2},{"name":"x","other":3}]}`,
+			expected:   `{"a":[{"name":"x","value":2,"other":3}]}`,
+		},
+		{
+			name:       "merge by key with duplicate new keys in source",
+			strategies: []*operatorv1alpha1.ValuesMergeStrategy{mergeByKey},
+			x:          `{"a":[]}`,
+			y:          `{"a":[{"name":"x","value":1},{"name":"x","value":2}]}`,
+			expected:   `{"a":[{"name":"x","value":2}]}`,
+		},
+		{
+			name:       "merge by key with scalar items",
+			strategies: []*operatorv1alpha1.ValuesMergeStrategy{mergeByKey},
+			x:          `{"a":["x",1]}`,
+			y:          `{"a":["x",2]}`,
+			expected:   `{"a":["x",1,"x",2]}`,
+		},
+		{
+			name:       "merge by key with non-scalar keys",
+			strategies: []*operatorv1alpha1.ValuesMergeStrategy{mergeByKey},
+			x:          `{"a":[{"name":{"first":"x"},"value":1}]}`,
+			y:          `{"a":[{"name":{"first":"x"},"value":2}]}`,
+			expected:   `{"a":[{"name":{"first":"x"},"value":2}]}`,
+		},
+		{
+			name:       "merge by custom key",
+			strategies: []*operatorv1alpha1.ValuesMergeStrategy{{Lists: operatorv1alpha1.ListMergeStrategyMergeByKey, MergeKey: "id"}},
+			x:          `{"a":[{"id":1,"name":"x"}]}`,
+			y:          `{"a":[{"id":1,"name":"y"},{"id":2,"name":"x"}]}`,
+			expected:   `{"a":[{"id":1,"name":"y"},{"id":2,"name":"x"}]}`,
+		},
+		{
+			name:     "map replaced by list",
+			x:        `{"a":{"b":1}}`,
+			y:        `{"a":[1]}`,
+			expected: `{"a":[1]}`,
+		},
+		{
+			name:       "list replaced by map",
+			strategies: []*operatorv1alpha1.ValuesMergeStrategy{appendLists},
+			x:          `{"a":[1]}`,
+			y:          `{"a":{"b":1}}`,
+			expected:   `{"a":{"b":1}}`,
+		},
+		{
+			name:       "scalar replaced by list",
+			strategies: []*operatorv1alpha1.ValuesMergeStrategy{appendLists},
+			x:          `{"a":"x"}`,
+			y:          `{"a":[1]}`,
+			expected:   `{"a":[1]}`,
+		},
+		{
+			name:       "list replaced by null",
+			strategies: []*operatorv1alpha1.ValuesMergeStrategy{appendLists},
+			x:          `{"a":[1]}`,
+			y:          `{"a":null}`,
+			expected:   `{"a":null}`,
+		},
+		{
+			name: "path strategies",
+			strategies: []*operatorv1alpha1.ValuesMergeStrategy{{
+				Lists: operatorv1alpha1.ListMergeStrategyAppend,
+				Paths: []operatorv1alpha1.ValuesPathMergeStrategy{
+					{Path: "a.b", Strategy: operatorv1alpha1.ListMergeStrategyReplace},
+					{Path: "c", Strategy: operatorv1alpha1.ListMergeStrategyMergeByKey, MergeKey: "id"},
+					{Path: `d\.e`, Strategy: operatorv1alpha1.ListMergeStrategyReplace},
+				},
+			}},
+			x:        `{"a":{"b":[1],"x":[1]},"c":[{"id":1,"v":1}],"d.e":[1],"d":{"e":[1]}}`,
+			y:        `{"a":{"b":[2],"x":[2]},"c":[{"id":1,"v":2}],"d.e":[2],"d":{"e":[2]}}`,
+			expected: `{"a":{"b":[2],"x":[1,2]},"c":[{"id":1,"v":2}],"d.e":[2],"d":{"e":[1,2]}}`,
+		},
+		{
+			name:       "later strategies take precedence",
+			strategies: []*operatorv1alpha1.ValuesMergeStrategy{appendLists, nil, mergeByKey},
+			x:          `{"a":[{"name":"x","v":1}]}`,
+			y:          `{"a":[{"name":"x","v":2}]}`,
+			expected:   `{"a":[{"name":"x","v":2}]}`,
+		},
+		{
+			name: "later merge key applies to earlier strategy",
+			strategies: []*operatorv1alpha1.ValuesMergeStrategy{
+				mergeByKey,
+				{MergeKey: "id"},
+			},
+			x:        `{"a":[{"id":1,"name":"x"}]}`,
+			y:        `{"a":[{"id":1,"name":"y"}]}`,
+			expected: `{"a":[{"id":1,"name":"y"}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			merger, err := newValuesMerger(test.strategies...)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			x := parseTestValues(t, test.x)
+			y := parseTestValues(t, test.y)
+			merger.merge(x, y)
+			if expected := parseTestValues(t, test.expected); !reflect.DeepEqual(x, expected) {
+				actual, _ := json.Marshal(x)
+				t.Errorf("expected %s, got %s", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestValuesMergerDoesNotModifySource(t *testing.T) {
+	merger, err := newValuesMerger(&operatorv1alpha1.ValuesMergeStrategy{Lists: operatorv1alpha1.ListMergeStrategyAppend})
+	if err != nil {
+		t.Fatal(err)
+	}
+	x := parseTestValues(t, `{"a":[1]}`)
+	y := parseTestValues(t, `{"a":[2]}`)
+	merger.merge(x, y)
+	merger.merge(x, y)
+	if expected := parseTestValues(t, `{"a":[2]}`); !reflect.DeepEqual(y, expected) {
+		t.Errorf("source values were modified: %v", y)
+	}
+}
+
+func TestNewValuesMergerInvalidPath(t *testing.T) {
+	for _, path := range []string{"a[0]", "a..b", `a\`} {
+		_, err := newValuesMerger(&operatorv1alpha1.ValuesMergeStrategy{
+			Paths: []operatorv1alpha1.ValuesPathMergeStrategy{{Path: path, Strategy: operatorv1alpha1.ListMergeStrategyAppend}},
+		})
+		if err == nil || !strings.Contains(err.Error(), "invalid") {
+			t.Errorf("expected path %s to be rejected, got %v", path, err)
+		}
+	}
+}
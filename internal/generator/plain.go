@@ -0,0 +1,167 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package generator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	kyaml "sigs.k8s.io/yaml"
+
+	"github.com/sap/component-operator-runtime/pkg/manifests"
+	componentoperatorruntimetypes "github.com/sap/component-operator-runtime/pkg/types"
+)
+
+// PlainGenerator renders all YAML and JSON files found (recursively) below a given directory. Files may contain multiple
+// (YAML) documents, and lists (of kind List). If templating is enabled, the files are rendered as Go templates (with sprig
+// functions) before being parsed; the template data contains the fields .Values, .Namespace and .Name.
+type PlainGenerator struct {
+	files     map[string][]byte
+	paths     []string
+	templates *template.Template
+}
+
+var _ manifests.Generator = &PlainGenerator{}
+
+// Create a new PlainGenerator, reading the files below dir in fsys. The given decryptor (which may be nil) is applied
+// to the content of all files.
+func NewPlainGenerator(fsys fs.FS, dir string, templating bool, decryptor manifests.Decryptor) (*PlainGenerator, error) {
+	g := &PlainGenerator{files: make(map[string][]byte)}
+	dir = path.Clean(dir)
+	if dir == "" {
+		dir = "."
+	}
+	if err := fs.WalkDir(fsys, dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isPlainManifestFile(p) {
+			return nil
+		}
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		if decryptor != nil {
+			data, err = decryptor.Decrypt(data, p)
+			if err != nil {
+				return err
+			}
+		}
+		// note: fs.WalkDir walks in lexical order, so the order of paths is deterministic
+		g.paths = append(g.paths, p)
+		g.files[p] = data
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if len(g.paths) == 0 {
+		return nil, fmt.Errorf("no YAML or JSON files found in %s", dir)
+	}
+
+	if templating {
+		g.templates = template.New("").Option("missingkey=zero").Funcs(sprig.TxtFuncMap()).Funcs(template.FuncMap{
+			"toYaml": toYaml,
+		})
+		for _, p := range g.paths {
+			if _, err := g.templates.New(p).Parse(string(g.files[p])); err != nil {
+				return nil, fmt.Errorf("error parsing template %s: %w", p, err)
+			}
+		}
+	}
+
+	return g, nil
+}
+
+// Implement the manifests.Generator interface.
+func (g *PlainGenerator) Generate(ctx context.Context, namespace string, name string, parameters componentoperatorruntimetypes.Unstructurable) ([]client.Object, error) {
+	data := map[string]any{
+		"Values":    parameters.ToUnstructured(),
+		"Namespace": namespace,
+		"Name":      name,
+	}
+
+	var objects []client.Object
+	for _, p := range g.paths {
+		content := g.files[p]
+		if g.templates != nil {
+			var buf bytes.Buffer
+			if err := g.templates.ExecuteTemplate(&buf, p, data); err != nil {
+				return nil, fmt.Errorf("error rendering template %s: %w", p, err)
+			}
+			content = buf.Bytes()
+		}
+		fileObjects, err := decodeObjects(content)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding %s: %w", p, err)
+		}
+		objects = append(objects, fileObjects...)
+	}
+	return objects, nil
+}
+
+func isPlainManifestFile(p string) bool {
+	switch strings.ToLower(path.Ext(p)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
+// decode all (YAML or JSON) documents contained in the given data; empty documents are skipped, lists are expanded
+func decodeObjects(data []byte) ([]client.Object, error) {
+	var objects []client.Object
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewBuffer(data), 4096)
+	for {
+		var object map[string]any
+		if err := decoder.Decode(&object); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		if len(object) == 0 {
+			continue
+		}
+		u := &unstructured.Unstructured{Object: object}
+		if u.GetAPIVersion() == "" || u.GetKind() == "" {
+			return nil, fmt.Errorf("object without apiVersion or kind")
+		}
+		if strings.HasSuffix(u.GetKind(), "List") && u.IsList() {
+			list, err := u.ToList()
+			if err != nil {
+				return nil, err
+			}
+			for i := range list.Items {
+				objects = append(objects, &list.Items[i])
+			}
+			continue
+		}
+		objects = append(objects, u)
+	}
+	return objects, nil
+}
+
+func toYaml(value any) (string, error) {
+	raw, err := kyaml.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(raw), "\n"), nil
+}
@@ -9,26 +9,27 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // TODO: consolidate all the util files into an internal reuse package
 
+// maximum number of nested symbolic links to directories followed by copyDirectory (protects against link cycles)
+const maxSymlinkDepth = 16
+
+// merge y into x, recursively for maps; lists and scalars are replaced
 func deepMerge(x map[string]any, y map[string]any) {
-	for k := range y {
-		if _, ok := x[k]; ok {
-			if v, ok := x[k].(map[string]any); ok {
-				if w, ok := y[k].(map[string]any); ok {
-					deepMerge(v, w)
-				} else {
-					x[k] = w
-				}
-			} else {
-				x[k] = y[k]
-			}
-		} else {
-			x[k] = y[k]
-		}
-	}
+	(&valuesMerger{}).merge(x, y)
 }
 
 func shallowMerge[T any](x map[string]T, y map[string]T) {
@@ -49,3 +50,236 @@ func calculateDigest(values ...any) string {
 	}
 	return sha256hex(raw)
 }
+
+func directorySize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+// copy the directory dir (within fsys) to targetPath; symbolic links are resolved within fsys and replaced by a copy
+// of their target, so links pointing to other locations within fsys (such as a sibling directory of dir) keep working;
+// fsys has to confine the resolution of links (as the file systems returned by os.Root.FS do), such that links pointing
+// outside of fsys are rejected
+func copyDirectory(fsys fs.FS, dir string, targetPath string) error {
+	if err := os.MkdirAll(targetPath, 0755); err != nil {
+		return err
+	}
+	root, err := os.OpenRoot(targetPath)
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+	return copyDirectoryToRoot(fsys, dir, root, ".", 0)
+}
+
+func copyDirectoryToRoot(fsys fs.FS, srcDir string, root *os.Root, dstDir string, depth int) error {
+	entries, err := fs.ReadDir(fsys, srcDir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		src := path.Join(srcDir, entry.Name())
+		dst := path.Join(dstDir, entry.Name())
+		// note: in contrast to fs.DirEntry.Info(), fs.Stat follows symbolic links
+		info, err := fs.Stat(fsys, src)
+		if err != nil {
+			if entry.Type()&fs.ModeSymlink != 0 {
+				return fmt.Errorf("symbolic link %s cannot be resolved within the source: %w", src, err)
+			}
+			return err
+		}
+		switch {
+		case info.IsDir():
+			subdirDepth := depth
+			if entry.Type()&fs.ModeSymlink != 0 {
+				subdirDepth++
+				if subdirDepth > maxSymlinkDepth {
+					return fmt.Errorf("too many levels of symbolic links at %s", src)
+				}
+			}
+			if err := root.Mkdir(dst, 0755); err != nil {
+				return err
+			}
+			if err := copyDirectoryToRoot(fsys, src, root, dst, subdirDepth); err != nil {
+				return err
+			}
+		case info.Mode().IsRegular():
+			if err := copyFileToRoot(fsys, src, root, dst, info.Mode()); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%s has unsupported type %s", src, info.Mode().Type())
+		}
+	}
+	return nil
+}
+
+func copyFileToRoot(fsys fs.FS, src string, root *os.Root, dst string, mode fs.FileMode) error {
+	reader, err := fsys.Open(src)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	perm := fs.FileMode(0644)
+	if mode&0111 != 0 {
+		perm = 0755
+	}
+	writer, err := root.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+	if _, err := io.Copy(writer, reader); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// collect the objects contained in the given (json-like) value; the value may be a single object (or a list of kind List),
+// an array, or a map whose values contain objects (recursively); null values are skipped
+func collectObjects(value any, objects *[]client.Object) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []any:
+		for _, item := range v {
+			if err := collectObjects(item, objects); err != nil {
+				return err
+			}
+		}
+		return nil
+	case map[string]any:
+		if _, ok := v["kind"]; !ok {
+			// note: iterate in a deterministic order
+			keys := make([]string, 0, len(v))
+			for key := range v {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				if err := collectObjects(v[key], objects); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		fileObjects, err := decodeObjects(raw)
+		if err != nil {
+			return err
+		}
+		*objects = append(*objects, fileObjects...)
+		return nil
+	default:
+		return fmt.Errorf("unexpected value of type %T in rendered output", value)
+	}
+}
+
+// parse a value path (such as a.b[0].c, in the notation of helm's --set flag) into a list of keys (string) and indices (int);
+// dots (and brackets) contained in keys can be escaped by a backslash
+func parseValuePath(p string) ([]any, error) {
+	var result []any
+	var key strings.Builder
+	keyPending := false
+	for i := 0; i < len(p); i++ {
+		switch c := p[i]; c {
+		case '\\':
+			if i+1 == len(p) {
+				return nil, fmt.Errorf("invalid value path %s: trailing backslash", p)
+			}
+			i++
+			key.WriteByte(p[i])
+			keyPending = true
+		case '.':
+			if keyPending {
+				result = append(result, key.String())
+				key.Reset()
+				keyPending = false
+			} else if i == 0 || p[i-1] != ']' {
+				return nil, fmt.Errorf("invalid value path %s: empty key", p)
+			}
+		case '[':
+			if keyPending {
+				result = append(result, key.String())
+				key.Reset()
+				keyPending = false
+			} else if i == 0 || p[i-1] != ']' {
+				return nil, fmt.Errorf("invalid value path %s: index without key", p)
+			}
+			j := strings.IndexByte(p[i:], ']')
+			if j < 0 {
+				return nil, fmt.Errorf("invalid value path %s: missing ]", p)
+			}
+			index, err := strconv.Atoi(p[i+1 : i+j])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid value path %s: invalid index %s", p, p[i+1:i+j])
+			}
+			result = append(result, index)
+			i += j
+			if i+1 < len(p) && p[i+1] != '.' && p[i+1] != '[' {
+				return nil, fmt.Errorf("invalid value path %s: unexpected character after ]", p)
+			}
+		default:
+			key.WriteByte(c)
+			keyPending = true
+		}
+	}
+	if keyPending {
+		result = append(result, key.String())
+	} else if len(p) == 0 || p[len(p)-1] == '.' {
+		return nil, fmt.Errorf("invalid value path %s: empty key", p)
+	}
+	return result, nil
+}
+
+// set the value at the given path (as returned by parseValuePath); intermediate maps and lists are created
+// (or replaced) as needed, lists are padded with nil values
+func setValue(values map[string]any, p []any, value any) error {
+	var current any = values
+	var assign func(v any)
+	for i, segment := range p {
+		var next any
+		switch s := segment.(type) {
+		case string:
+			m, ok := current.(map[string]any)
+			if !ok {
+				m = make(map[string]any)
+				assign(m)
+			}
+			next = m[s]
+			assign = func(v any) { m[s] = v }
+		case int:
+			l, _ := current.([]any)
+			if s >= len(l) {
+				l = append(l, make([]any, s+1-len(l))...)
+				assign(l)
+			}
+			next = l[s]
+			assign = func(v any) { l[s] = v }
+		default:
+			panic("this cannot happen")
+		}
+		if i == len(p)-1 {
+			assign(value)
+			return nil
+		}
+		current = next
+	}
+	return fmt.Errorf("empty value path")
+}
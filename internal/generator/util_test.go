@@ -0,0 +1,232 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorv1alpha1 "github.com/sap/component-operator/api/v1alpha1"
+	"github.com/sap/component-operator/internal/testutil"
+)
+
+func TestCopyDirectory(t *testing.T) {
+	tests := []struct {
+		name          string
+		links         map[string]string
+		expectedFiles map[string]string
+		expectedErr   string
+	}{
+		{name: "no links", expectedFiles: map[string]string{"src/file.yaml": "file"}},
+		{name: "link to file outside of copied directory", links: map[string]string{"src/link.yaml": "../shared/file.yaml"}, expectedFiles: map[string]string{"src/link.yaml": "shared"}},
+		{name: "link to directory outside of copied directory", links: map[string]string{"src/link": "../shared"}, expectedFiles: map[string]string{"src/link/file.yaml": "shared"}},
+		{name: "chained links", links: map[string]string{"src/link.yaml": "../chain.yaml", "chain.yaml": "shared/file.yaml"}, expectedFiles: map[string]string{"src/link.yaml": "shared"}},
+		{name: "link escaping source", links: map[string]string{"src/link.yaml": "../../outside.yaml"}, expectedErr: "symbolic link src/link.yaml cannot be resolved within the source"},
+		{name: "absolute link", links: map[string]string{"src/link.yaml": "/etc/hostname"}, expectedErr: "symbolic link src/link.yaml cannot be resolved within the source"},
+		{name: "dangling link", links: map[string]string{"src/link.yaml": "missing.yaml"}, expectedErr: "symbolic link src/link.yaml cannot be resolved within the source"},
+		{name: "link cycle", links: map[string]string{"src/loop": "."}, expectedErr: "too many levels of symbolic links"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sourcePath := filepath.Join(t.TempDir(), "source")
+			writeTestTree(t, sourcePath, map[string]string{"src/file.yaml": "file", "shared/file.yaml": "shared"}, test.links)
+			writeTestTree(t, filepath.Dir(sourcePath), map[string]string{"outside.yaml": "outside"}, nil)
+			root, err := os.OpenRoot(sourcePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer root.Close()
+
+			targetPath := filepath.Join(t.TempDir(), "target")
+			err = copyDirectory(root.FS(), "src", filepath.Join(targetPath, "src"))
+			if testutil.CheckError(t, err, test.expectedErr) {
+				return
+			}
+			for name, expectedContent := range test.expectedFiles {
+				content, err := os.ReadFile(filepath.Join(targetPath, filepath.FromSlash(name)))
+				if err != nil {
+					t.Fatalf("expected file %s: %s", name, err)
+				}
+				if string(content) != expectedContent {
+					t.Errorf("file %s: expected content %q, got %q", name, expectedContent, content)
+				}
+			}
+			if err := filepath.WalkDir(targetPath, func(p string, d os.DirEntry, err error) error {
+				if err == nil && d.Type()&os.ModeSymlink != 0 {
+					t.Errorf("expected no symbolic links in copy, found %s", p)
+				}
+				return err
+			}); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestParseValuePath(t *testing.T) {
+	tests := []struct {
+		path        string
+		expected    []any
+		expectedErr string
+	}{
+		{path: "a", expected: []any{"a"}},
+		{path: "a.b.c", expected: []any{"a", "b", "c"}},
+		{path: "a[0]", expected: []any{"a", 0}},
+		{path: "a[0].b", expected: []any{"a", 0, "b"}},
+		{path: "a[1][2]", expected: []any{"a", 1, 2}},
+		{path: "a[10].b[0]", expected: []any{"a", 10, "b", 0}},
+		{path: `a\.b.c`, expected: []any{"a.b", "c"}},
+		{path: `a\[0\]`, expected: []any{"a[0]"}},
+		{path: `a\\.b`, expected: []any{`a\`, "b"}},
+		{path: `\.`, expected: []any{"."}},
+		{path: `nginx\.ingress\.kubernetes\.io/rewrite-target`, expected: []any{"nginx.ingress.kubernetes.io/rewrite-target"}},
+		{path: "", expectedErr: "empty key"},
+		{path: ".a", expectedErr: "empty key"},
+		{path: "a.", expectedErr: "empty key"},
+		{path: "a..b", expectedErr: "empty key"},
+		{path: "a[0].", expectedErr: "empty key"},
+		{path: "[0]", expectedErr: "index without key"},
+		{path: "a.[0]", expectedErr: "index without key"},
+		{path: "a[0", expectedErr: "missing ]"},
+		{path: "a[]", expectedErr: "invalid index"},
+		{path: "a[x]", expectedErr: "invalid index"},
+		{path: "a[-1]", expectedErr: "invalid index"},
+		{path: "a[0]b", expectedErr: "unexpected character after ]"},
+		{path: `a\`, expectedErr: "trailing backslash"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			p, err := parseValuePath(test.path)
+			if testutil.CheckError(t, err, test.expectedErr) {
+				return
+			}
+			if !reflect.DeepEqual(p, test.expected) {
+				t.Errorf("expected %#v, got %#v", test.expected, p)
+			}
+		})
+	}
+}
+
+func TestSetValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   string
+		path     string
+		value    any
+		expected string
+	}{
+		{name: "empty values", values: `{}`, path: "a.b", value: "x", expected: `{"a":{"b":"x"}}`},
+		{name: "existing map keeps siblings", values: `{"a":{"c":1}}`, path: "a.b", value: "x", expected: `{"a":{"b":"x","c":1}}`},
+		{name: "existing scalar is overwritten", values: `{"a":{"b":1}}`, path: "a.b", value: "x", expected: `{"a":{"b":"x"}}`},
+		{name: "existing scalar replaced by map", values: `{"a":"x"}`, path: "a.b", value: 1, expected: `{"a":{"b":1}}`},
+		{name: "existing null replaced by map", values: `{"a":null}`, path: "a.b", value: 1, expected: `{"a":{"b":1}}`},
+		{name: "existing scalar replaced by list", values: `{"a":"x"}`, path: "a[1]", value: 1, expected: `{"a":[null,1]}`},
+		{name: "existing map replaced by list", values: `{"a":{"b":1}}`, path: "a[0]", value: 1, expected: `{"a":[1]}`},
+		{name: "existing list replaced by map", values: `{"a":[1]}`, path: "a.b", value: 1, expected: `{"a":{"b":1}}`},
+		{name: "existing list item", values: `{"a":[1,2,3]}`, path: "a[1]", value: "x", expected: `{"a":[1,"x",3]}`},
+		{name: "existing list is padded", values: `{"a":[1]}`, path: "a[2]", value: "x", expected: `{"a":[1,null,"x"]}`},
+		{name: "map within list item", values: `{"a":[{"b":1}]}`, path: "a[0].c", value: 2, expected: `{"a":[{"b":1,"c":2}]}`},
+		{name: "nested lists", values: `{}`, path: "a[1][0]", value: true, expected: `{"a":[null,[true]]}`},
+		{name: "map value", values: `{"a":"x"}`, path: "a", value: map[string]any{"b": "y"}, expected: `{"a":{"b":"y"}}`},
+		{name: "escaped key", values: `{"a":{"b":1}}`, path: `a\.b`, value: 2, expected: `{"a":{"b":1},"a.b":2}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			values := parseTestValues(t, test.values)
+			p, err := parseValuePath(test.path)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if err := setValue(values, p, test.value); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			expected := parseTestValues(t, test.expected)
+			// note: compare the json representation, since numbers are float64 after unmarshalling
+			actualJson, _ := json.Marshal(values)
+			expectedJson, _ := json.Marshal(expected)
+			if string(actualJson) != string(expectedJson) {
+				t.Errorf("expected %s, got %s", expectedJson, actualJson)
+			}
+		})
+	}
+
+	if err := setValue(map[string]any{}, nil, "x"); err == nil {
+		t.Errorf("expected empty path to be rejected")
+	}
+}
+
+func TestValuesFromReference(t *testing.T) {
+	clnt := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "secret"},
+			Data: map[string][]byte{
+				"values.yaml": []byte("a:\n  b: 1\n"),
+				"tag":         []byte("1.10\n"),
+				"list":        []byte("- a\n- b\n"),
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "secret-without-values"},
+			Data:       map[string][]byte{"other": []byte("a: 1\n")},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "configmap"},
+			Data:       map[string]string{"values": "c: true\n"},
+		},
+	).Build()
+	component := &operatorv1alpha1.Component{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "component"}}
+
+	tests := []struct {
+		name        string
+		ref         operatorv1alpha1.ValuesReference
+		expected    string
+		expectedErr string
+	}{
+		{name: "secret with default key", ref: operatorv1alpha1.ValuesReference{Name: "secret"}, expected: `{"a":{"b":1}}`},
+		{name: "configmap with default key", ref: operatorv1alpha1.ValuesReference{Kind: operatorv1alpha1.ValuesReferenceKindConfigMap, Name: "configmap"}, expected: `{"c":true}`},
+		{name: "document at target path", ref: operatorv1alpha1.ValuesReference{Name: "secret", TargetPath: "x.y"}, expected: `{"x":{"y":{"a":{"b":1}}}}`},
+		{name: "scalar at target path is kept as string", ref: operatorv1alpha1.ValuesReference{Name: "secret", Key: "tag", TargetPath: "image.tag"}, expected: `{"image":{"tag":"1.10"}}`},
+		{name: "list at target path", ref: operatorv1alpha1.ValuesReference{Name: "secret", Key: "list", TargetPath: "l"}, expected: `{"l":["a","b"]}`},
+		{name: "optional missing object", ref: operatorv1alpha1.ValuesReference{Name: "missing", Optional: true}, expected: `null`},
+		{name: "optional missing key", ref: operatorv1alpha1.ValuesReference{Name: "secret", Key: "missing", Optional: true}, expected: `null`},
+		{name: "optional missing object with target path", ref: operatorv1alpha1.ValuesReference{Kind: operatorv1alpha1.ValuesReferenceKindConfigMap, Name: "missing", TargetPath: "a", Optional: true}, expected: `null`},
+		{name: "optional existing key", ref: operatorv1alpha1.ValuesReference{Name: "secret", Optional: true}, expected: `{"a":{"b":1}}`},
+		{name: "missing object", ref: operatorv1alpha1.ValuesReference{Name: "missing"}, expectedErr: "Secret test/missing not found"},
+		{name: "missing key", ref: operatorv1alpha1.ValuesReference{Name: "secret", Key: "missing"}, expectedErr: "key missing not found"},
+		{name: "missing default keys", ref: operatorv1alpha1.ValuesReference{Name: "secret-without-values"}, expectedErr: "none of the keys values, values.yaml, values.yml found"},
+		{name: "invalid target path", ref: operatorv1alpha1.ValuesReference{Name: "secret", TargetPath: "a..b"}, expectedErr: "invalid value path"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ref := test.ref
+			err := ref.Load(context.Background(), clnt, component)
+			var values map[string]any
+			if err == nil {
+				values, err = valuesFromReference(&ref)
+			}
+			if testutil.CheckError(t, err, test.expectedErr) {
+				return
+			}
+			actual, _ := json.Marshal(values)
+			if string(actual) != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, actual)
+			}
+		})
+	}
+}
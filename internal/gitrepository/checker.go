@@ -0,0 +1,97 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gitrepository
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/sap/component-operator-runtime/pkg/component"
+
+	operatorv1alpha1 "github.com/sap/component-operator/api/v1alpha1"
+	componentcache "github.com/sap/component-operator/internal/cache/component"
+	"github.com/sap/component-operator/internal/gitrepository/util"
+)
+
+type checker struct {
+	client              client.Client
+	cache               cache.Cache
+	componentReconciler *component.Reconciler[*operatorv1alpha1.Component]
+	logger              logr.Logger
+}
+
+var _ manager.Runnable = &checker{}
+var _ manager.LeaderElectionRunnable = &checker{}
+
+func newChecker(clnt client.Client, cache cache.Cache, componentReconciler *component.Reconciler[*operatorv1alpha1.Component], logger logr.Logger) *checker {
+	return &checker{
+		client:              clnt,
+		cache:               cache,
+		componentReconciler: componentReconciler,
+		logger:              logger,
+	}
+}
+
+func (c *checker) Start(ctx context.Context) error {
+	ticker := time.NewTicker(60 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		componentList := &operatorv1alpha1.ComponentList{}
+		if err := c.cache.List(context.TODO(), componentList, componentcache.HasGitRepository()); err != nil {
+			c.logger.Error(err, "error listing components")
+			continue
+		}
+		for _, component := range componentList.Items {
+			repository := component.Spec.SourceRef.GitRepository
+			var ref util.Ref
+			if repository.Ref != nil {
+				ref = util.Ref(*repository.Ref)
+			}
+			if ref.Commit != "" {
+				// pinned commits cannot move, so there is no need to poll the repository
+				continue
+			}
+			var credentials *util.Credentials
+			if repository.SecretRef != nil {
+				var err error
+				credentials, err = util.GetCredentials(context.TODO(), c.client, component.Namespace, repository.SecretRef.Name)
+				if err != nil {
+					c.logger.Error(err, "error reading credentials for git repository", "url", repository.Url, "secret", repository.SecretRef.Name)
+					continue
+				}
+			}
+			_, digest, revision, err := util.GetArtifact(repository.Url, ref, credentials)
+			if err == nil {
+				if digest != component.Status.LastAttemptedDigest || revision != component.Status.LastAttemptedRevision {
+					c.componentReconciler.Trigger(component.Namespace, component.Name)
+				}
+			} else {
+				c.logger.Error(err, "error fetching revision from git repository", "url", repository.Url)
+			}
+		}
+	}
+}
+
+func (c *checker) NeedLeaderElection() bool {
+	return true
+}
+
+func SetupWithManager(mgr manager.Manager, componentReconciler *component.Reconciler[*operatorv1alpha1.Component]) error {
+	mgr.Add(newChecker(mgr.GetClient(), mgr.GetCache(), componentReconciler, mgr.GetLogger()))
+	return nil
+}
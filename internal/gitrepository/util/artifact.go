@@ -0,0 +1,289 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+// note: the artifact URLs returned by GetArtifact() have the form git+<repository url>#<ref>@<commit>,
+// where ref is the fully qualified git reference (e.g. refs/heads/main), or empty, if a commit was explicitly requested
+const artifactUrlPrefix = "git+"
+
+const (
+	refsHeadsPrefix = "refs/heads/"
+	refsTagsPrefix  = "refs/tags/"
+	peeledSuffix    = "^{}"
+)
+
+const (
+	listTimeout     = 1 * time.Minute
+	checkoutTimeout = 5 * time.Minute
+)
+
+// note: the local reference the fetched commit is stored under (before it is checked out)
+const checkoutRef = "refs/heads/checkout"
+
+var commitPattern = regexp.MustCompile(`^[a-f0-9]{40}$`)
+
+// Git reference to be resolved. Precedence is commit, semver, tag, branch. If none is specified,
+// the default branch (HEAD) of the remote repository is used.
+type Ref struct {
+	Branch string
+	Tag    string
+	SemVer string
+	Commit string
+}
+
+// Check if the given URL is an artifact URL, as returned by GetArtifact().
+func IsArtifactUrl(url string) bool {
+	return strings.HasPrefix(url, artifactUrlPrefix)
+}
+
+// Resolve the given reference in the given git repository, and return the artifact URL, digest (in the format sha1:<commit>)
+// and revision (in the flux format, e.g. main@sha1:<commit> or v1.0.0@sha1:<commit>).
+func GetArtifact(repositoryUrl string, ref Ref, credentials *Credentials) (string, string, string, error) {
+	if err := validateRepositoryUrl(repositoryUrl); err != nil {
+		return "", "", "", err
+	}
+
+	var refs map[string]string
+	var head string
+	if ref.Commit == "" {
+		var err error
+		refs, head, err = listRemote(repositoryUrl, credentials)
+		if err != nil {
+			return "", "", "", err
+		}
+	}
+	name, qualifiedRef, commit, err := resolveRef(refs, head, ref)
+	if err != nil {
+		return "", "", "", err
+	}
+	if commit == "" {
+		return "", "", "", fmt.Errorf("reference %s not found in git repository %s", qualifiedRef, redact(repositoryUrl))
+	}
+
+	digest := "sha1:" + commit
+	revision := digest
+	if name != "" {
+		revision = name + "@" + digest
+	}
+	artifactUrl := fmt.Sprintf("%s%s#%s@%s", artifactUrlPrefix, repositoryUrl, qualifiedRef, commit)
+	return artifactUrl, digest, revision, nil
+}
+
+// Check out the commit identified by the given artifact URL (as returned by GetArtifact()) into the specified directory,
+// which must exist and be empty. The git metadata directory is removed after the checkout.
+func Checkout(artifactUrl string, credentials *Credentials, targetPath string) error {
+	repositoryUrl, fragment, ok := strings.Cut(strings.TrimPrefix(artifactUrl, artifactUrlPrefix), "#")
+	if !IsArtifactUrl(artifactUrl) || !ok {
+		return fmt.Errorf("invalid git artifact URL: %s", redact(artifactUrl))
+	}
+	qualifiedRef, commit, ok := strings.Cut(fragment, "@")
+	if !ok || !commitPattern.MatchString(commit) {
+		return fmt.Errorf("invalid git artifact URL: %s", redact(artifactUrl))
+	}
+	if err := validateRepositoryUrl(repositoryUrl); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), checkoutTimeout)
+	defer cancel()
+
+	auth, err := credentials.authMethod(repositoryUrl)
+	if err != nil {
+		return err
+	}
+	repository, err := git.PlainInit(targetPath, false)
+	if err != nil {
+		return err
+	}
+	if _, err := repository.CreateRemote(&config.RemoteConfig{Name: git.DefaultRemoteName, URLs: []string{repositoryUrl}}); err != nil {
+		return err
+	}
+	fetch := func(src string) error {
+		err := repository.FetchContext(ctx, &git.FetchOptions{
+			RefSpecs: []config.RefSpec{config.RefSpec("+" + src + ":" + checkoutRef)},
+			Depth:    1,
+			Auth:     auth,
+			CABundle: credentials.caBundle(),
+			Tags:     git.NoTags,
+		})
+		if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+			return fmt.Errorf("error fetching %s from git repository %s: %w", src, redact(repositoryUrl), err)
+		}
+		return nil
+	}
+	// try to fetch the commit directly (which is supported by most git servers); if that fails, fetch the
+	// reference the commit was resolved from, and check if it still points to the requested commit
+	if err := fetch(commit); err != nil {
+		if qualifiedRef == "" {
+			return err
+		}
+		if err := fetch(qualifiedRef); err != nil {
+			return err
+		}
+	}
+	fetched, err := repository.Reference(checkoutRef, true)
+	if err != nil {
+		return err
+	}
+	hash := fetched.Hash()
+	if tag, err := repository.TagObject(hash); err == nil {
+		// note: annotated tags have to be peeled to the commit they point to
+		c, err := tag.Commit()
+		if err != nil {
+			return err
+		}
+		hash = c.Hash
+	}
+	if hash.String() != commit {
+		return fmt.Errorf("reference %s in git repository %s no longer points to commit %s", qualifiedRef, redact(repositoryUrl), commit)
+	}
+	worktree, err := repository.Worktree()
+	if err != nil {
+		return err
+	}
+	if err := worktree.Checkout(&git.CheckoutOptions{Hash: hash, Force: true}); err != nil {
+		return err
+	}
+	return os.RemoveAll(filepath.Join(targetPath, ".git"))
+}
+
+// resolve the given reference against the given remote references (a map of fully qualified references to commits, as returned
+// by listRemote()), and the given reference of the default branch; return the short name of the resolved reference (if any),
+// the fully qualified reference (if any), and the commit (empty if the reference does not exist)
+func resolveRef(refs map[string]string, head string, ref Ref) (string, string, string, error) {
+	switch {
+	case ref.Commit != "":
+		if !commitPattern.MatchString(ref.Commit) {
+			return "", "", "", fmt.Errorf("invalid commit (must be a full sha1 commit hash): %s", ref.Commit)
+		}
+		return ref.Branch, "", ref.Commit, nil
+	case ref.SemVer != "":
+		constraint, err := semver.NewConstraint(ref.SemVer)
+		if err != nil {
+			return "", "", "", fmt.Errorf("invalid semver constraint %s: %w", ref.SemVer, err)
+		}
+		var name string
+		var latest *semver.Version
+		for r := range refs {
+			tag, ok := strings.CutPrefix(r, refsTagsPrefix)
+			if !ok || strings.HasSuffix(tag, peeledSuffix) {
+				continue
+			}
+			version, err := semver.NewVersion(tag)
+			if err != nil {
+				continue
+			}
+			// note: if two tags denote the same version (such as v1.0.0 and 1.0.0), the choice must not depend on the map order
+			if constraint.Check(version) && (latest == nil || version.GreaterThan(latest) || version.Equal(latest) && tag < name) {
+				latest = version
+				name = tag
+			}
+		}
+		if latest == nil {
+			return "", "", "", fmt.Errorf("no tag found matching semver constraint %s", ref.SemVer)
+		}
+		return name, refsTagsPrefix + name, peeled(refs, refsTagsPrefix+name), nil
+	case ref.Tag != "":
+		return ref.Tag, refsTagsPrefix + ref.Tag, peeled(refs, refsTagsPrefix+ref.Tag), nil
+	default:
+		name := ref.Branch
+		if name == "" {
+			if !strings.HasPrefix(head, refsHeadsPrefix) {
+				return "", "", "", fmt.Errorf("unable to determine default branch of git repository")
+			}
+			name = strings.TrimPrefix(head, refsHeadsPrefix)
+		}
+		return name, refsHeadsPrefix + name, refs[refsHeadsPrefix+name], nil
+	}
+}
+
+// return a map of (fully qualified) references to commits (including peeled tags, with suffix ^{}), and the reference
+// the default branch (HEAD) of the remote repository points to (empty if unknown)
+func listRemote(repositoryUrl string, credentials *Credentials) (map[string]string, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), listTimeout)
+	defer cancel()
+
+	auth, err := credentials.authMethod(repositoryUrl)
+	if err != nil {
+		return nil, "", err
+	}
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{Name: git.DefaultRemoteName, URLs: []string{repositoryUrl}})
+	list, err := remote.ListContext(ctx, &git.ListOptions{
+		Auth:          auth,
+		CABundle:      credentials.caBundle(),
+		PeelingOption: git.AppendPeeled,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("error listing references of git repository %s: %w", redact(repositoryUrl), err)
+	}
+	refs, head := parseRefs(list)
+	return refs, head, nil
+}
+
+// convert the given advertised references into a map of (fully qualified) references to commits, and return it,
+// together with the target of the symbolic HEAD reference (empty if the server did not advertise it)
+func parseRefs(list []*plumbing.Reference) (map[string]string, string) {
+	refs := make(map[string]string)
+	var head string
+	for _, r := range list {
+		switch {
+		case r.Type() == plumbing.SymbolicReference && r.Name() == plumbing.HEAD:
+			head = r.Target().String()
+		case r.Type() == plumbing.HashReference:
+			refs[r.Name().String()] = r.Hash().String()
+		}
+	}
+	return refs, head
+}
+
+// return the commit an annotated tag points to (or the referenced commit itself, if ref is not an annotated tag)
+func peeled(refs map[string]string, ref string) string {
+	if commit, ok := refs[ref+peeledSuffix]; ok {
+		return commit
+	}
+	return refs[ref]
+}
+
+func validateRepositoryUrl(repositoryUrl string) error {
+	u, err := url.Parse(repositoryUrl)
+	if err != nil {
+		return fmt.Errorf("invalid git repository URL: %w", err)
+	}
+	switch u.Scheme {
+	case "https", "http", "ssh":
+	default:
+		return fmt.Errorf("invalid git repository URL %s: scheme must be one of https, http, ssh", redact(repositoryUrl))
+	}
+	if u.Host == "" || strings.HasPrefix(u.Host, "-") {
+		return fmt.Errorf("invalid git repository URL %s: missing or invalid host", redact(repositoryUrl))
+	}
+	return nil
+}
+
+func redact(repositoryUrl string) string {
+	if u, err := url.Parse(repositoryUrl); err == nil {
+		return u.Redacted()
+	}
+	return repositoryUrl
+}
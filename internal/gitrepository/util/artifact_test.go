@@ -0,0 +1,162 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+
+	"github.com/sap/component-operator/internal/testutil"
+)
+
+const (
+	commitMain    = "1111111111111111111111111111111111111111"
+	commitDevelop = "2222222222222222222222222222222222222222"
+	commitV100    = "3333333333333333333333333333333333333333"
+	commitV110    = "4444444444444444444444444444444444444444"
+	tagObjectV110 = "5555555555555555555555555555555555555555"
+	commitV200    = "6666666666666666666666666666666666666666"
+)
+
+func testRefs() []*plumbing.Reference {
+	return []*plumbing.Reference{
+		plumbing.NewSymbolicReference(plumbing.HEAD, "refs/heads/main"),
+		plumbing.NewHashReference("refs/heads/main", plumbing.NewHash(commitMain)),
+		plumbing.NewHashReference("refs/heads/develop", plumbing.NewHash(commitDevelop)),
+		plumbing.NewHashReference("refs/tags/v1.0.0", plumbing.NewHash(commitV100)),
+		// annotated tag: the tag object and the peeled commit are advertised
+		plumbing.NewHashReference("refs/tags/v1.1.0", plumbing.NewHash(tagObjectV110)),
+		plumbing.NewHashReference("refs/tags/v1.1.0^{}", plumbing.NewHash(commitV110)),
+		plumbing.NewHashReference("refs/tags/v2.0.0-rc.1", plumbing.NewHash(commitV200)),
+		plumbing.NewHashReference("refs/tags/not-a-version", plumbing.NewHash(commitV200)),
+	}
+}
+
+func TestParseRefs(t *testing.T) {
+	refs, head := parseRefs(testRefs())
+	if head != "refs/heads/main" {
+		t.Errorf("expected HEAD to point to refs/heads/main, got %q", head)
+	}
+	if _, ok := refs["HEAD"]; ok {
+		t.Errorf("symbolic reference HEAD must not be contained in the reference map")
+	}
+	if refs["refs/tags/v1.1.0^{}"] != commitV110 {
+		t.Errorf("expected peeled tag to be contained in the reference map")
+	}
+
+	_, head = parseRefs(testRefs()[1:])
+	if head != "" {
+		t.Errorf("expected empty HEAD if no symref was advertised, got %q", head)
+	}
+}
+
+func TestResolveRef(t *testing.T) {
+	refs, head := parseRefs(testRefs())
+
+	tests := []struct {
+		name         string
+		head         string
+		ref          Ref
+		expectedName string
+		expectedRef  string
+		expectedSha  string
+		expectedErr  string
+	}{
+		{name: "default branch", head: head, expectedName: "main", expectedRef: "refs/heads/main", expectedSha: commitMain},
+		{name: "default branch unknown", head: "", expectedErr: "unable to determine default branch"},
+		{name: "branch", head: head, ref: Ref{Branch: "develop"}, expectedName: "develop", expectedRef: "refs/heads/develop", expectedSha: commitDevelop},
+		{name: "missing branch", head: head, ref: Ref{Branch: "missing"}, expectedName: "missing", expectedRef: "refs/heads/missing"},
+		{name: "lightweight tag", ref: Ref{Tag: "v1.0.0"}, expectedName: "v1.0.0", expectedRef: "refs/tags/v1.0.0", expectedSha: commitV100},
+		{name: "annotated tag is peeled", ref: Ref{Tag: "v1.1.0"}, expectedName: "v1.1.0", expectedRef: "refs/tags/v1.1.0", expectedSha: commitV110},
+		{name: "tag takes precedence over branch", ref: Ref{Branch: "main", Tag: "v1.0.0"}, expectedName: "v1.0.0", expectedRef: "refs/tags/v1.0.0", expectedSha: commitV100},
+		{name: "semver selects latest match", ref: Ref{SemVer: "^1.0.0"}, expectedName: "v1.1.0", expectedRef: "refs/tags/v1.1.0", expectedSha: commitV110},
+		{name: "semver excludes prereleases", ref: Ref{SemVer: ">=1.0.0"}, expectedName: "v1.1.0", expectedRef: "refs/tags/v1.1.0", expectedSha: commitV110},
+		{name: "semver includes prereleases if requested", ref: Ref{SemVer: ">=2.0.0-0"}, expectedName: "v2.0.0-rc.1", expectedRef: "refs/tags/v2.0.0-rc.1", expectedSha: commitV200},
+		{name: "semver exact", ref: Ref{SemVer: "1.0.0"}, expectedName: "v1.0.0", expectedRef: "refs/tags/v1.0.0", expectedSha: commitV100},
+		{name: "semver without match", ref: Ref{SemVer: "^3.0.0"}, expectedErr: "no tag found"},
+		{name: "invalid semver", ref: Ref{SemVer: "foo"}, expectedErr: "invalid semver constraint"},
+		{name: "commit", ref: Ref{Commit: commitMain}, expectedSha: commitMain},
+		{name: "commit with branch", ref: Ref{Branch: "main", Commit: commitMain}, expectedName: "main", expectedSha: commitMain},
+		{name: "short commit", ref: Ref{Commit: "1111111"}, expectedErr: "invalid commit"},
+		{name: "sha256 commit", ref: Ref{Commit: strings.Repeat("a", 64)}, expectedErr: "invalid commit"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			name, qualifiedRef, commit, err := resolveRef(refs, test.head, test.ref)
+			if testutil.CheckError(t, err, test.expectedErr) {
+				return
+			}
+			if name != test.expectedName || qualifiedRef != test.expectedRef || commit != test.expectedSha {
+				t.Errorf("expected (%q, %q, %q), got (%q, %q, %q)", test.expectedName, test.expectedRef, test.expectedSha, name, qualifiedRef, commit)
+			}
+		})
+	}
+}
+
+func TestResolveRefSemVerIsDeterministic(t *testing.T) {
+	refs := map[string]string{
+		"refs/tags/v1.0.0": commitV100,
+		"refs/tags/1.0.0":  commitMain,
+	}
+	for i := 0; i < 20; i++ {
+		name, _, commit, err := resolveRef(refs, "", Ref{SemVer: "1.0.0"})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if name != "1.0.0" || commit != commitMain {
+			t.Fatalf("expected tag 1.0.0 to be selected, got %s", name)
+		}
+	}
+}
+
+func TestAuthMethod(t *testing.T) {
+	basic := &Credentials{Username: "user", Password: "pass"}
+	token := &Credentials{BearerToken: "token"}
+
+	if auth, err := basic.authMethod("https://example.com/repo.git"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if a, ok := auth.(*githttp.BasicAuth); !ok || a.Username != "user" || a.Password != "pass" {
+		t.Errorf("expected basic auth, got %v", auth)
+	}
+	if auth, err := token.authMethod("https://example.com/repo.git"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if a, ok := auth.(*githttp.TokenAuth); !ok || a.Token != "token" {
+		t.Errorf("expected token auth, got %v", auth)
+	}
+	for _, c := range []*Credentials{basic, token} {
+		if _, err := c.authMethod("http://example.com/repo.git"); err == nil || !strings.Contains(err.Error(), "insecure connection") {
+			t.Errorf("expected credentials to be refused over http, got %v", err)
+		}
+	}
+	for _, c := range []*Credentials{nil, {CA: []byte("ca")}} {
+		if auth, err := c.authMethod("http://example.com/repo.git"); err != nil || auth != nil {
+			t.Errorf("expected anonymous access over http, got %v, %v", auth, err)
+		}
+	}
+	for _, c := range []*Credentials{nil, basic, {KnownHosts: []byte("example.com ssh-ed25519 AAAA")}} {
+		if _, err := c.authMethod("ssh://git@example.com/repo.git"); err == nil || !strings.Contains(err.Error(), "requires credentials") {
+			t.Errorf("expected ssh access without identity and known hosts to be refused, got %v", err)
+		}
+	}
+}
+
+func TestCheckoutRejectsInvalidArtifactUrl(t *testing.T) {
+	for _, artifactUrl := range []string{
+		"https://example.com/repo.git",
+		"git+https://example.com/repo.git",
+		"git+https://example.com/repo.git#refs/heads/main@1111111",
+		"git+file:///tmp/repo#refs/heads/main@" + commitMain,
+		"git+ext::sh -c touch% /tmp/pwned#@" + commitMain,
+	} {
+		if err := Checkout(artifactUrl, nil, t.TempDir()); err == nil {
+			t.Errorf("expected artifact URL %s to be rejected", artifactUrl)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	corev1 "k8s.io/api/core/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// note: the secret keys are compatible with the ones used by flux GitRepository
+const (
+	SecretKeyUsername    = "username"
+	SecretKeyPassword    = "password"
+	SecretKeyBearerToken = "bearerToken"
+	SecretKeyIdentity    = "identity"
+	SecretKeyKnownHosts  = "known_hosts"
+	SecretKeyCA          = "ca.crt"
+)
+
+// Credentials used to access a git repository. A nil *Credentials is valid and means that
+// the repository is accessed anonymously.
+type Credentials struct {
+	Username    string
+	Password    string
+	BearerToken string
+	Identity    []byte
+	KnownHosts  []byte
+	CA          []byte
+}
+
+// Create credentials from the data of a secret (see the SecretKey* constants for the supported keys).
+func NewCredentials(data map[string][]byte) (*Credentials, error) {
+	credentials := &Credentials{
+		Username:    string(data[SecretKeyUsername]),
+		Password:    string(data[SecretKeyPassword]),
+		BearerToken: string(data[SecretKeyBearerToken]),
+		Identity:    data[SecretKeyIdentity],
+		KnownHosts:  data[SecretKeyKnownHosts],
+		CA:          data[SecretKeyCA],
+	}
+	if credentials.Password != "" && credentials.Username == "" {
+		return nil, fmt.Errorf("invalid credentials: %s requires %s", SecretKeyPassword, SecretKeyUsername)
+	}
+	if credentials.Password != "" && credentials.BearerToken != "" {
+		return nil, fmt.Errorf("invalid credentials: %s and %s are mutually exclusive", SecretKeyPassword, SecretKeyBearerToken)
+	}
+	if len(credentials.Identity) > 0 && len(credentials.KnownHosts) == 0 {
+		return nil, fmt.Errorf("invalid credentials: %s requires %s", SecretKeyIdentity, SecretKeyKnownHosts)
+	}
+	return credentials, nil
+}
+
+// Read credentials from the specified secret.
+func GetCredentials(ctx context.Context, clnt client.Reader, namespace string, name string) (*Credentials, error) {
+	secret := &corev1.Secret{}
+	if err := clnt.Get(ctx, apitypes.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+		return nil, err
+	}
+	return NewCredentials(secret.Data)
+}
+
+// return the go-git authentication method to be used for the given repository URL; credentials are never sent
+// over plain http, and ssh access requires an identity and known hosts (strict host key checking)
+func (c *Credentials) authMethod(repositoryUrl string) (transport.AuthMethod, error) {
+	u, err := url.Parse(repositoryUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid git repository URL: %w", err)
+	}
+	switch u.Scheme {
+	case "ssh":
+		if c == nil || len(c.Identity) == 0 || len(c.KnownHosts) == 0 {
+			return nil, fmt.Errorf("ssh access to git repositories requires credentials containing %s and %s", SecretKeyIdentity, SecretKeyKnownHosts)
+		}
+		user := u.User.Username()
+		if user == "" {
+			user = "git"
+		}
+		auth, err := gitssh.NewPublicKeys(user, c.Identity, "")
+		if err != nil {
+			return nil, fmt.Errorf("error parsing ssh identity: %w", err)
+		}
+		hostKeyCallback, err := newHostKeyCallback(c.KnownHosts)
+		if err != nil {
+			return nil, err
+		}
+		auth.HostKeyCallback = hostKeyCallback
+		return auth, nil
+	default:
+		if c == nil || c.Username == "" && c.BearerToken == "" {
+			return nil, nil
+		}
+		if u.Scheme != "https" {
+			return nil, fmt.Errorf("refusing to send credentials to git repository %s over an insecure connection (use https)", redact(repositoryUrl))
+		}
+		if c.BearerToken != "" {
+			return &githttp.TokenAuth{Token: c.BearerToken}, nil
+		}
+		return &githttp.BasicAuth{Username: c.Username, Password: c.Password}, nil
+	}
+}
+
+// return the CA bundle to be used (in addition to the system trust store) when accessing https repositories
+func (c *Credentials) caBundle() []byte {
+	if c == nil {
+		return nil
+	}
+	return c.CA
+}
+
+// note: the known hosts data is written to a temporary file, since the known_hosts parser (which also handles hashed
+// and wildcard entries) only accepts files; the file is read immediately, and therefore can be removed right away
+func newHostKeyCallback(knownHostsData []byte) (ssh.HostKeyCallback, error) {
+	file, err := os.CreateTemp("", "known_hosts-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(knownHostsData); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if err := file.Close(); err != nil {
+		return nil, err
+	}
+	hostKeyCallback, err := knownhosts.New(file.Name())
+	if err != nil {
+		return nil, fmt.Errorf("invalid credentials: error parsing %s: %w", SecretKeyKnownHosts, err)
+	}
+	return hostKeyCallback, nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/go-logr/logr"
 
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	"github.com/sap/component-operator-runtime/pkg/component"
@@ -22,6 +23,7 @@ import (
 )
 
 type checker struct {
+	client              client.Client
 	cache               cache.Cache
 	componentReconciler *component.Reconciler[*operatorv1alpha1.Component]
 	logger              logr.Logger
@@ -30,8 +32,9 @@ type checker struct {
 var _ manager.Runnable = &checker{}
 var _ manager.LeaderElectionRunnable = &checker{}
 
-func newChecker(cache cache.Cache, componentReconciler *component.Reconciler[*operatorv1alpha1.Component], logger logr.Logger) *checker {
+func newChecker(clnt client.Client, cache cache.Cache, componentReconciler *component.Reconciler[*operatorv1alpha1.Component], logger logr.Logger) *checker {
 	return &checker{
+		client:              clnt,
 		cache:               cache,
 		componentReconciler: componentReconciler,
 		logger:              logger,
@@ -57,7 +60,16 @@ func (c *checker) Start(ctx context.Context) error {
 			url := component.Spec.SourceRef.HttpRepository.Url
 			digestHeader := component.Spec.SourceRef.HttpRepository.DigestHeader
 			revisionHeader := component.Spec.SourceRef.HttpRepository.RevisionHeader
-			_, digest, revision, err := util.GetArtifact(url, digestHeader, revisionHeader)
+			var credentials *util.Credentials
+			if secretRef := component.Spec.SourceRef.HttpRepository.SecretRef; secretRef != nil {
+				var err error
+				credentials, err = util.GetCredentials(context.TODO(), c.client, component.Namespace, secretRef.Name)
+				if err != nil {
+					c.logger.Error(err, "error reading credentials for http repository", "url", url, "secret", secretRef.Name)
+					continue
+				}
+			}
+			_, digest, revision, err := util.GetArtifact(url, digestHeader, revisionHeader, credentials)
 			if err == nil {
 				if digest != component.Status.LastAttemptedDigest || revision != component.Status.LastAttemptedRevision {
 					c.componentReconciler.Trigger(component.Namespace, component.Name)
@@ -74,6 +86,6 @@ func (c *checker) NeedLeaderElection() bool {
 }
 
 func SetupWithManager(mgr manager.Manager, componentReconciler *component.Reconciler[*operatorv1alpha1.Component]) error {
-	mgr.Add(newChecker(mgr.GetCache(), componentReconciler, mgr.GetLogger()))
+	mgr.Add(newChecker(mgr.GetClient(), mgr.GetCache(), componentReconciler, mgr.GetLogger()))
 	return nil
 }
@@ -10,7 +10,7 @@ import (
 	"net/http"
 )
 
-func GetArtifact(url string, digestHeader string, revisionHeader string) (string, string, string, error) {
+func GetArtifact(url string, digestHeader string, revisionHeader string, credentials *Credentials) (string, string, string, error) {
 	if digestHeader == "" {
 		digestHeader = "etag"
 	}
@@ -18,18 +18,26 @@ func GetArtifact(url string, digestHeader string, revisionHeader string) (string
 		revisionHeader = digestHeader
 	}
 
-	httpClient := http.Client{
-		CheckRedirect: func(req *http.Request, _ []*http.Request) error {
-			if req.Response.Header.Get(digestHeader) != "" {
-				return http.ErrUseLastResponse
-			}
-			return nil
-		},
-	}
-	resp, err := httpClient.Head(url)
+	httpClient, err := credentials.NewHttpClient()
 	if err != nil {
 		return "", "", "", err
 	}
+	httpClient.CheckRedirect = func(req *http.Request, _ []*http.Request) error {
+		if req.Response.Header.Get(digestHeader) != "" {
+			return http.ErrUseLastResponse
+		}
+		return nil
+	}
+	req, err := http.NewRequest(http.MethodHead, url, nil)
+	if err != nil {
+		return "", "", "", err
+	}
+	credentials.Authorize(req)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", "", "", err
+	}
+	defer resp.Body.Close()
 	switch {
 	case resp.StatusCode >= 400:
 		return "", "", "", fmt.Errorf("error calling source reference URL: %d (%s)", resp.StatusCode, http.StatusText(resp.StatusCode))
@@ -0,0 +1,112 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package util
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+
+	corev1 "k8s.io/api/core/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	SecretKeyUsername    = "username"
+	SecretKeyPassword    = "password"
+	SecretKeyBearerToken = "bearerToken"
+	SecretKeyCert        = "tls.crt"
+	SecretKeyKey         = "tls.key"
+	SecretKeyCA          = "ca.crt"
+)
+
+// Credentials used to access a http repository. A nil *Credentials is valid and means that
+// no authentication is performed (and the system trust store is used).
+type Credentials struct {
+	Username    string
+	Password    string
+	BearerToken string
+	Cert        []byte
+	Key         []byte
+	CA          []byte
+}
+
+// Create credentials from the data of a secret (see the SecretKey* constants for the supported keys).
+func NewCredentials(data map[string][]byte) (*Credentials, error) {
+	credentials := &Credentials{
+		Username:    string(data[SecretKeyUsername]),
+		Password:    string(data[SecretKeyPassword]),
+		BearerToken: string(data[SecretKeyBearerToken]),
+		Cert:        data[SecretKeyCert],
+		Key:         data[SecretKeyKey],
+		CA:          data[SecretKeyCA],
+	}
+	if (credentials.Username == "") != (credentials.Password == "") {
+		return nil, fmt.Errorf("invalid credentials: %s and %s must be specified together", SecretKeyUsername, SecretKeyPassword)
+	}
+	if credentials.Username != "" && credentials.BearerToken != "" {
+		return nil, fmt.Errorf("invalid credentials: %s and %s are mutually exclusive", SecretKeyUsername, SecretKeyBearerToken)
+	}
+	if (len(credentials.Cert) == 0) != (len(credentials.Key) == 0) {
+		return nil, fmt.Errorf("invalid credentials: %s and %s must be specified together", SecretKeyCert, SecretKeyKey)
+	}
+	return credentials, nil
+}
+
+// Read credentials from the specified secret.
+func GetCredentials(ctx context.Context, clnt client.Reader, namespace string, name string) (*Credentials, error) {
+	secret := &corev1.Secret{}
+	if err := clnt.Get(ctx, apitypes.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+		return nil, err
+	}
+	return NewCredentials(secret.Data)
+}
+
+// Return a http client honoring the TLS related settings (client certificate, CA bundle) of the credentials.
+func (c *Credentials) NewHttpClient() (*http.Client, error) {
+	if c == nil || len(c.Cert) == 0 && len(c.CA) == 0 {
+		return &http.Client{}, nil
+	}
+	tlsConfig := &tls.Config{}
+	if len(c.Cert) > 0 {
+		cert, err := tls.X509KeyPair(c.Cert, c.Key)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	if len(c.CA) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(c.CA) {
+			return nil, fmt.Errorf("error parsing CA bundle: no valid certificates found")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport}, nil
+}
+
+// Add authorization header (basic or bearer) to the given request.
+// Note: the header is set on the initial request only; when following redirects, the http client will
+// drop it if the redirect target is not on the same domain (or a subdomain) of the original host.
+func (c *Credentials) Authorize(req *http.Request) {
+	if c == nil {
+		return
+	}
+	switch {
+	case c.Username != "":
+		req.SetBasicAuth(c.Username, c.Password)
+	case c.BearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+c.BearerToken)
+	}
+}
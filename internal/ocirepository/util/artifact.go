@@ -0,0 +1,348 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	mediaTypeOciManifest    = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeFluxContent    = "application/vnd.cncf.flux.content.v1.tar+gzip"
+)
+
+// note: the artifact URLs returned by GetArtifact() have the form oci://<host>/<repository>@<layer digest>;
+// for registries accessed through plain http, the scheme oci+http:// is used instead
+const (
+	schemeOci     = "oci://"
+	schemeOciHttp = "oci+http://"
+)
+
+var (
+	repositoryUrlPattern = regexp.MustCompile(`^oci://([^/]+)/([a-z0-9]+(?:[._-][a-z0-9]+)*(?:/[a-z0-9]+(?:[._-][a-z0-9]+)*)*)$`)
+	artifactUrlPattern   = regexp.MustCompile(`^(oci|oci\+http)://([^/]+)/([^@]+)@([a-z0-9]+:[a-f0-9]+)$`)
+	digestPattern        = regexp.MustCompile(`^[a-z0-9]+:[a-f0-9]+$`)
+)
+
+type manifest struct {
+	MediaType string       `json:"mediaType"`
+	Layers    []descriptor `json:"layers"`
+}
+
+type descriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+// Check if the given URL is an artifact URL, as returned by GetArtifact().
+func IsArtifactUrl(url string) bool {
+	return strings.HasPrefix(url, schemeOci) || strings.HasPrefix(url, schemeOciHttp)
+}
+
+// Resolve the given tag or digest in the given OCI repository (oci://<host>/<repository>), and return the artifact URL
+// (pointing to the selected layer), the digest of the manifest, and the revision (in the format <tag>@<manifest digest>, like flux).
+// If digest is specified, tag is only used to build the revision. If layerMediaType is empty, the first layer having
+// the flux content media type, or the first layer at all, will be selected.
+func GetArtifact(repositoryUrl string, tag string, digest string, layerMediaType string, insecure bool, credentials *Credentials) (string, string, string, error) {
+	m := repositoryUrlPattern.FindStringSubmatch(repositoryUrl)
+	if m == nil {
+		return "", "", "", fmt.Errorf("invalid OCI repository URL: %s", repositoryUrl)
+	}
+	host := m[1]
+	repository := m[2]
+
+	if digest != "" && !digestPattern.MatchString(digest) {
+		return "", "", "", fmt.Errorf("invalid digest: %s", digest)
+	}
+	if tag == "" && digest == "" {
+		tag = "latest"
+	}
+
+	client, err := newRegistryClient(host, repository, insecure, credentials)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	reference := digest
+	if reference == "" {
+		reference = tag
+	}
+	resp, err := client.get("manifests/"+reference, mediaTypeOciManifest+", "+mediaTypeDockerManifest)
+	if err != nil {
+		return "", "", "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4*1024*1024))
+	if err != nil {
+		return "", "", "", err
+	}
+	sum := sha256.Sum256(body)
+	manifestDigest := "sha256:" + hex.EncodeToString(sum[:])
+	if digest != "" && digest != manifestDigest {
+		return "", "", "", fmt.Errorf("digest of retrieved manifest (%s) does not match requested digest (%s)", manifestDigest, digest)
+	}
+
+	manifest := &manifest{}
+	if err := json.Unmarshal(body, manifest); err != nil {
+		return "", "", "", fmt.Errorf("error parsing manifest %s: %w", reference, err)
+	}
+	if manifest.MediaType == "" {
+		manifest.MediaType = resp.Header.Get("Content-Type")
+	}
+	if manifest.MediaType != mediaTypeOciManifest && manifest.MediaType != mediaTypeDockerManifest {
+		return "", "", "", fmt.Errorf("unsupported manifest media type: %s", manifest.MediaType)
+	}
+	layer, err := selectLayer(manifest.Layers, layerMediaType)
+	if err != nil {
+		return "", "", "", err
+	}
+	if !digestPattern.MatchString(layer.Digest) {
+		return "", "", "", fmt.Errorf("invalid layer digest: %s", layer.Digest)
+	}
+
+	scheme := schemeOci
+	if insecure {
+		scheme = schemeOciHttp
+	}
+	artifactUrl := fmt.Sprintf("%s%s/%s@%s", scheme, host, repository, layer.Digest)
+	revision := manifestDigest
+	if tag != "" {
+		revision = tag + "@" + manifestDigest
+	}
+	return artifactUrl, manifestDigest, revision, nil
+}
+
+// Download the layer identified by the given artifact URL (as returned by GetArtifact()).
+// The caller has to close the returned reader.
+func DownloadArtifact(artifactUrl string, credentials *Credentials) (io.ReadCloser, error) {
+	m := artifactUrlPattern.FindStringSubmatch(artifactUrl)
+	if m == nil {
+		return nil, fmt.Errorf("invalid OCI artifact URL: %s", artifactUrl)
+	}
+	insecure := m[1] == "oci+http"
+	host := m[2]
+	repository := m[3]
+	digest := m[4]
+
+	client, err := newRegistryClient(host, repository, insecure, credentials)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.get("blobs/"+digest, "")
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Return the digest of the layer identified by the given artifact URL (as returned by GetArtifact()).
+func GetLayerDigest(artifactUrl string) (string, error) {
+	m := artifactUrlPattern.FindStringSubmatch(artifactUrl)
+	if m == nil {
+		return "", fmt.Errorf("invalid OCI artifact URL: %s", artifactUrl)
+	}
+	return m[4], nil
+}
+
+func selectLayer(layers []descriptor, mediaType string) (*descriptor, error) {
+	if len(layers) == 0 {
+		return nil, fmt.Errorf("manifest has no layers")
+	}
+	if mediaType != "" {
+		for i := range layers {
+			if layers[i].MediaType == mediaType {
+				return &layers[i], nil
+			}
+		}
+		return nil, fmt.Errorf("manifest has no layer with media type %s", mediaType)
+	}
+	for i := range layers {
+		if layers[i].MediaType == mediaTypeFluxContent {
+			return &layers[i], nil
+		}
+	}
+	return &layers[0], nil
+}
+
+type registryClient struct {
+	baseUrl       string
+	host          string
+	repository    string
+	insecure      bool
+	credentials   *Credentials
+	httpClient    *http.Client
+	authorization string
+}
+
+func newRegistryClient(host string, repository string, insecure bool, credentials *Credentials) (*registryClient, error) {
+	apiHost := host
+	if normalizeRegistryHost(host) == "index.docker.io" {
+		apiHost = "registry-1.docker.io"
+		if !strings.Contains(repository, "/") {
+			repository = "library/" + repository
+		}
+	}
+	scheme := "https"
+	if insecure {
+		scheme = "http"
+	}
+	httpClient, err := credentials.newHttpClient()
+	if err != nil {
+		return nil, err
+	}
+	return &registryClient{
+		baseUrl:     fmt.Sprintf("%s://%s/v2/%s/", scheme, apiHost, repository),
+		host:        host,
+		repository:  repository,
+		insecure:    insecure,
+		credentials: credentials,
+		httpClient:  httpClient,
+	}, nil
+}
+
+// perform GET request for the given path (relative to /v2/<repository>/); if the registry responds with an authentication challenge,
+// try to authenticate and repeat the request once; the caller has to close the body of the returned response
+func (c *registryClient) get(path string, accept string) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequest(http.MethodGet, c.baseUrl+path, nil)
+		if err != nil {
+			return nil, err
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		if c.authorization != "" {
+			req.Header.Set("Authorization", c.authorization)
+		}
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusUnauthorized && attempt == 0 {
+			challenge := resp.Header.Get("WWW-Authenticate")
+			resp.Body.Close()
+			if err := c.authenticate(challenge); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("error retrieving %s: %s", req.URL.Redacted(), resp.Status)
+		}
+		return resp, nil
+	}
+}
+
+func (c *registryClient) authenticate(challenge string) error {
+	scheme, params := parseChallenge(challenge)
+	auth, hasAuth := c.credentials.lookup(c.host)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if !hasAuth || auth.Username == "" {
+			return fmt.Errorf("registry %s requires authentication, but no credentials were provided", c.host)
+		}
+		req := &http.Request{Header: make(http.Header)}
+		req.SetBasicAuth(auth.Username, auth.Password)
+		c.authorization = req.Header.Get("Authorization")
+		return nil
+	case "bearer":
+		if hasAuth && auth.RegistryToken != "" {
+			c.authorization = "Bearer " + auth.RegistryToken
+			return nil
+		}
+		realm := params["realm"]
+		if realm == "" {
+			return fmt.Errorf("invalid authentication challenge from registry %s: missing realm", c.host)
+		}
+		tokenUrl, err := url.Parse(realm)
+		if err != nil {
+			return fmt.Errorf("invalid authentication challenge from registry %s: %w", c.host, err)
+		}
+		// note: the credentials are sent to the realm, so it must not be accessed through plain http (unless the registry itself is)
+		if !(tokenUrl.Scheme == "https" || tokenUrl.Scheme == "http" && c.insecure) || tokenUrl.Host == "" {
+			return fmt.Errorf("invalid authentication challenge from registry %s: realm %s must be an https URL", c.host, tokenUrl.Redacted())
+		}
+		query := tokenUrl.Query()
+		if service := params["service"]; service != "" {
+			query.Set("service", service)
+		}
+		scope := params["scope"]
+		if scope == "" {
+			scope = fmt.Sprintf("repository:%s:pull", c.repository)
+		}
+		query.Set("scope", scope)
+		tokenUrl.RawQuery = query.Encode()
+		req, err := http.NewRequest(http.MethodGet, tokenUrl.String(), nil)
+		if err != nil {
+			return err
+		}
+		if hasAuth && auth.Username != "" {
+			req.SetBasicAuth(auth.Username, auth.Password)
+		}
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("error retrieving token for registry %s: %s", c.host, resp.Status)
+		}
+		token := struct {
+			Token       string `json:"token"`
+			AccessToken string `json:"access_token"`
+		}{}
+		if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+			return fmt.Errorf("error parsing token response from registry %s: %w", c.host, err)
+		}
+		if token.Token == "" {
+			token.Token = token.AccessToken
+		}
+		if token.Token == "" {
+			return fmt.Errorf("empty token received from registry %s", c.host)
+		}
+		c.authorization = "Bearer " + token.Token
+		return nil
+	default:
+		return fmt.Errorf("unsupported authentication challenge from registry %s: %s", c.host, challenge)
+	}
+}
+
+// parse a WWW-Authenticate header value, such as: Bearer realm="https://auth.example.io/token",service="registry.example.io",scope="repository:foo:pull"
+func parseChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := make(map[string]string)
+	for rest = strings.TrimSpace(rest); rest != ""; rest = strings.TrimSpace(rest) {
+		key, value, ok := strings.Cut(rest, "=")
+		if !ok {
+			break
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				params[key] = value[1:]
+				break
+			}
+			params[key] = value[1 : end+1]
+			rest = strings.TrimPrefix(strings.TrimSpace(value[end+2:]), ",")
+		} else {
+			value, rest, _ = strings.Cut(value, ",")
+			params[key] = strings.TrimSpace(value)
+		}
+	}
+	return scheme, params
+}
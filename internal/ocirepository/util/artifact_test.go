@@ -0,0 +1,204 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sap/component-operator/internal/testutil"
+)
+
+// in-process registry serving a single repository (test/artifact) with tag latest; if token is not empty,
+// requests must carry it as bearer token, which is issued by the realm (basic auth user:pass)
+type testRegistry struct {
+	server        *httptest.Server
+	token         string
+	realm         string
+	layer         []byte
+	manifest      []byte
+	realmRequests atomic.Int32
+}
+
+func newTestRegistry(t *testing.T, token string) *testRegistry {
+	r := &testRegistry{token: token, layer: []byte("layer content")}
+	layerSum := sha256.Sum256(r.layer)
+	manifest, err := json.Marshal(map[string]any{
+		"schemaVersion": 2,
+		"mediaType":     mediaTypeOciManifest,
+		"layers": []map[string]any{
+			{"mediaType": "application/octet-stream", "digest": "sha256:0000", "size": 1},
+			{"mediaType": mediaTypeFluxContent, "digest": "sha256:" + hex.EncodeToString(layerSum[:]), "size": len(r.layer)},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.manifest = manifest
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, req *http.Request) {
+		r.realmRequests.Add(1)
+		if username, password, ok := req.BasicAuth(); !ok || username != "user" || password != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"token": r.token})
+	})
+	mux.HandleFunc("/v2/test/artifact/manifests/latest", func(w http.ResponseWriter, req *http.Request) {
+		if !r.authorized(w, req) {
+			return
+		}
+		w.Header().Set("Content-Type", mediaTypeOciManifest)
+		w.Write(r.manifest)
+	})
+	mux.HandleFunc("/v2/test/artifact/blobs/sha256:"+hex.EncodeToString(layerSum[:]), func(w http.ResponseWriter, req *http.Request) {
+		if !r.authorized(w, req) {
+			return
+		}
+		w.Write(r.layer)
+	})
+	r.server = httptest.NewTLSServer(mux)
+	t.Cleanup(r.server.Close)
+	r.realm = r.server.URL + "/token"
+	return r
+}
+
+func (r *testRegistry) authorized(w http.ResponseWriter, req *http.Request) bool {
+	if r.token == "" || req.Header.Get("Authorization") == "Bearer "+r.token {
+		return true
+	}
+	w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s",service="test"`, r.realm))
+	w.WriteHeader(http.StatusUnauthorized)
+	return false
+}
+
+func (r *testRegistry) host() string {
+	return strings.TrimPrefix(r.server.URL, "https://")
+}
+
+func (r *testRegistry) ca() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: r.server.Certificate().Raw})
+}
+
+func (r *testRegistry) manifestDigest() string {
+	sum := sha256.Sum256(r.manifest)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func newTestCredentials(t *testing.T, host string, ca []byte) *Credentials {
+	data := map[string][]byte{SecretKeyCA: ca}
+	if host != "" {
+		data[".dockerconfigjson"] = fmt.Appendf(nil, `{"auths":{%q:{"username":"user","password":"pass"}}}`, host)
+	}
+	credentials, err := NewCredentials(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return credentials
+}
+
+func TestGetArtifactWithCustomCA(t *testing.T) {
+	registry := newTestRegistry(t, "")
+
+	if _, _, _, err := GetArtifact("oci://"+registry.host()+"/test/artifact", "", "", "", false, nil); err == nil {
+		t.Fatal("expected error when registry certificate is not trusted")
+	}
+
+	credentials := newTestCredentials(t, "", registry.ca())
+	url, digest, revision, err := GetArtifact("oci://"+registry.host()+"/test/artifact", "", "", "", false, credentials)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if digest != registry.manifestDigest() {
+		t.Errorf("got digest %s, expected %s", digest, registry.manifestDigest())
+	}
+	if revision != "latest@"+registry.manifestDigest() {
+		t.Errorf("got revision %s, expected latest@%s", revision, registry.manifestDigest())
+	}
+
+	reader, err := DownloadArtifact(url, credentials)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != string(registry.layer) {
+		t.Errorf("got layer content %q, expected %q", content, registry.layer)
+	}
+}
+
+func TestGetArtifactWithBearerToken(t *testing.T) {
+	registry := newTestRegistry(t, "secret-token")
+	credentials := newTestCredentials(t, registry.host(), registry.ca())
+
+	if _, digest, _, err := GetArtifact("oci://"+registry.host()+"/test/artifact", "latest", "", "", false, credentials); err != nil {
+		t.Fatal(err)
+	} else if digest != registry.manifestDigest() {
+		t.Errorf("got digest %s, expected %s", digest, registry.manifestDigest())
+	}
+	if n := registry.realmRequests.Load(); n != 1 {
+		t.Errorf("got %d token requests, expected 1", n)
+	}
+}
+
+func TestGetArtifactRejectsPlainHttpRealm(t *testing.T) {
+	registry := newTestRegistry(t, "secret-token")
+	realm := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		t.Errorf("credentials were sent to plain http realm")
+	}))
+	defer realm.Close()
+	registry.realm = realm.URL + "/token"
+	credentials := newTestCredentials(t, registry.host(), registry.ca())
+
+	_, _, _, err := GetArtifact("oci://"+registry.host()+"/test/artifact", "latest", "", "", false, credentials)
+	testutil.CheckError(t, err, "must be an https URL")
+}
+
+func TestNewCredentials(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    map[string][]byte
+		wantErr bool
+	}{
+		{name: "empty", data: map[string][]byte{}, wantErr: true},
+		{name: "ca only", data: map[string][]byte{SecretKeyCA: []byte("ca")}},
+		{name: "docker config only", data: map[string][]byte{".dockerconfigjson": []byte(`{"auths":{}}`)}},
+		{name: "invalid docker config", data: map[string][]byte{".dockerconfigjson": []byte(`{`)}, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := NewCredentials(test.data); (err != nil) != test.wantErr {
+				t.Errorf("got error %v, expected error: %t", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(`Bearer realm="https://auth.example.io/token",service="registry.example.io",scope="repository:foo:pull"`)
+	if scheme != "Bearer" {
+		t.Errorf("got scheme %s, expected Bearer", scheme)
+	}
+	expected := map[string]string{"realm": "https://auth.example.io/token", "service": "registry.example.io", "scope": "repository:foo:pull"}
+	for key, value := range expected {
+		if params[key] != value {
+			t.Errorf("got %s=%q, expected %q", key, params[key], value)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package util
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	SecretKeyCA = "ca.crt"
+)
+
+// Registry credentials, as read from a docker config json, optionally along with a custom CA bundle.
+// A nil *Credentials is valid and means that registries are accessed anonymously (and the system trust store is used).
+type Credentials struct {
+	auths map[string]dockerAuth
+	ca    []byte
+}
+
+type dockerConfig struct {
+	Auths map[string]dockerAuth `json:"auths"`
+}
+
+type dockerAuth struct {
+	Username      string `json:"username,omitempty"`
+	Password      string `json:"password,omitempty"`
+	Auth          string `json:"auth,omitempty"`
+	RegistryToken string `json:"registrytoken,omitempty"`
+}
+
+// Create credentials from the data of a secret; the secret may contain a docker config json (key .dockerconfigjson,
+// as in secrets of type kubernetes.io/dockerconfigjson) and a custom CA bundle (key ca.crt); at least one of them must be present.
+func NewCredentials(data map[string][]byte) (*Credentials, error) {
+	raw, ok := data[corev1.DockerConfigJsonKey]
+	ca := data[SecretKeyCA]
+	if !ok && len(ca) == 0 {
+		return nil, fmt.Errorf("invalid registry credentials: missing key %s or %s", corev1.DockerConfigJsonKey, SecretKeyCA)
+	}
+	config := dockerConfig{}
+	if ok {
+		if err := json.Unmarshal(raw, &config); err != nil {
+			return nil, fmt.Errorf("invalid registry credentials: %w", err)
+		}
+	}
+	credentials := &Credentials{auths: make(map[string]dockerAuth), ca: ca}
+	for key, auth := range config.Auths {
+		if auth.Auth != "" && auth.Username == "" && auth.Password == "" {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("invalid registry credentials for %s: %w", key, err)
+			}
+			username, password, ok := strings.Cut(string(decoded), ":")
+			if !ok {
+				return nil, fmt.Errorf("invalid registry credentials for %s: malformed auth field", key)
+			}
+			auth.Username = username
+			auth.Password = password
+		}
+		credentials.auths[normalizeRegistryHost(key)] = auth
+	}
+	return credentials, nil
+}
+
+// Read credentials from the specified secret.
+func GetCredentials(ctx context.Context, clnt client.Reader, namespace string, name string) (*Credentials, error) {
+	secret := &corev1.Secret{}
+	if err := clnt.Get(ctx, apitypes.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+		return nil, err
+	}
+	return NewCredentials(secret.Data)
+}
+
+func (c *Credentials) lookup(host string) (dockerAuth, bool) {
+	if c == nil {
+		return dockerAuth{}, false
+	}
+	auth, ok := c.auths[normalizeRegistryHost(host)]
+	return auth, ok
+}
+
+// return a http client trusting the CA bundle of the credentials (in addition to the system trust store)
+func (c *Credentials) newHttpClient() (*http.Client, error) {
+	if c == nil || len(c.ca) == 0 {
+		return &http.Client{}, nil
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(c.ca) {
+		return nil, fmt.Errorf("error parsing CA bundle: no valid certificates found")
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	return &http.Client{Transport: transport}, nil
+}
+
+// normalize registry keys as they appear in docker config files (e.g. https://index.docker.io/v1/)
+func normalizeRegistryHost(host string) string {
+	host = strings.TrimPrefix(host, "https://")
+	host = strings.TrimPrefix(host, "http://")
+	host, _, _ = strings.Cut(host, "/")
+	switch host {
+	case "docker.io", "index.docker.io", "registry-1.docker.io":
+		return "index.docker.io"
+	}
+	return host
+}
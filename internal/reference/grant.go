@@ -0,0 +1,32 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package reference
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sap/component-operator/pkg/meta"
+)
+
+// Check if the given object may be referenced from the given namespace; this is the case if the object resides in that
+// namespace, if it is cluster-scoped, or if access is granted through the reference grant annotation of the object.
+func IsGranted(object metav1.Object, namespace string) bool {
+	if object.GetNamespace() == "" || object.GetNamespace() == namespace {
+		return true
+	}
+	grant, ok := object.GetAnnotations()[meta.AnnotationKeyReferenceGrant]
+	if !ok {
+		return false
+	}
+	for _, ns := range strings.Split(grant, ",") {
+		if ns = strings.TrimSpace(ns); ns == "*" || ns == namespace {
+			return true
+		}
+	}
+	return false
+}
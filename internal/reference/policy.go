@@ -0,0 +1,31 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package reference
+
+import (
+	"context"
+)
+
+// Policy determines how references of components to objects in other namespaces are handled.
+type Policy struct {
+	// Require references to objects in other namespaces to be granted by the referenced object
+	// (through the reference grant annotation).
+	NoCrossNamespaceRefs bool
+}
+
+type policyContextKey struct{}
+
+// Return a copy of the given context carrying the given policy.
+func NewContext(ctx context.Context, policy Policy) context.Context {
+	return context.WithValue(ctx, policyContextKey{}, policy)
+}
+
+// Return the policy carried by the given context; if the context carries no policy, the zero policy
+// (allowing all cross-namespace references) is returned.
+func PolicyFromContext(ctx context.Context) Policy {
+	policy, _ := ctx.Value(policyContextKey{}).(Policy)
+	return policy
+}
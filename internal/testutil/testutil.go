@@ -0,0 +1,29 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package testutil contains helpers shared by the tests of this module.
+package testutil
+
+import (
+	"strings"
+	"testing"
+)
+
+// Check the given error against the expected error. If expectedErr is empty, err must be nil; otherwise err must be
+// non-nil, and its message must contain expectedErr. Returns true if an error was expected, such that the caller can
+// skip checking further results.
+func CheckError(t testing.TB, err error, expectedErr string) bool {
+	t.Helper()
+	if expectedErr == "" {
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return false
+	}
+	if err == nil || !strings.Contains(err.Error(), expectedErr) {
+		t.Fatalf("expected error containing %q, got %v", expectedErr, err)
+	}
+	return true
+}
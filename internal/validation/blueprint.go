@@ -0,0 +1,112 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validation
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// Maximum total size (in bytes) of the files of a blueprint; blueprints are copied into blueprint versions,
+	// so this leaves some headroom below the size limit of etcd (1.5MiB per object).
+	BlueprintMaxTotalSize = 1024 * 1024
+	// Size (in bytes) from which on a warning is issued, since the blueprint is approaching BlueprintMaxTotalSize.
+	BlueprintWarningTotalSize = 768 * 1024
+	// Maximum length of a single path segment.
+	blueprintMaxSegmentLength = 255
+)
+
+// File extensions allowed in blueprints (compared case-insensitively).
+var BlueprintAllowedExtensions = []string{
+	".yaml", ".yml", ".json", ".tpl", ".txt", ".md",
+	".jsonnet", ".libsonnet", ".cue",
+	".tmpl", ".gotmpl", ".j2", ".jinja", ".toml", ".ini", ".conf", ".cfg", ".properties", ".env",
+	".lock", ".helmignore", ".gitignore", ".gitkeep",
+	".sh", ".bash", ".py", ".lua", ".rego", ".js", ".sql",
+	".xml", ".html", ".css", ".csv", ".tsv",
+	".pem", ".crt", ".cert", ".key", ".pub",
+}
+
+// File names without extension allowed in blueprints.
+var BlueprintAllowedNames = []string{
+	"LICENSE", "NOTICE", "README", "OWNERS", "CODEOWNERS", "AUTHORS", "CHANGELOG", "Makefile", "Dockerfile",
+}
+
+// Validate the files of a blueprint. All file paths must be clean, relative (slash-separated) paths, not containing '..',
+// and must have one of the allowed extensions (or one of the allowed names). The file contents must be valid UTF-8 text (binary
+// files, such as packaged charts, cannot be represented in a blueprint), and their total size must not exceed BlueprintMaxTotalSize.
+// Errors are reported per file; warnings are returned if the total size is approaching the limit.
+func ValidateBlueprintFiles(files map[string]string, fldPath *field.Path) ([]string, field.ErrorList) {
+	var warnings []string
+	var errs field.ErrorList
+
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		content := files[name]
+		if err := validateBlueprintFilePath(name); err != nil {
+			errs = append(errs, field.Invalid(fldPath.Key(name), name, err.Error()))
+			continue
+		}
+		if !isText(content) {
+			errs = append(errs, field.Invalid(fldPath.Key(name), fmt.Sprintf("<%d bytes>", len(content)), "binary content is not supported (must be valid UTF-8 text)"))
+		}
+	}
+
+	if totalSize := BlueprintTotalSize(files); totalSize > BlueprintMaxTotalSize {
+		errs = append(errs, field.Invalid(fldPath, fmt.Sprintf("<%d bytes>", totalSize), fmt.Sprintf("total size must not exceed %d bytes", BlueprintMaxTotalSize)))
+	} else if totalSize > BlueprintWarningTotalSize {
+		warnings = append(warnings, fmt.Sprintf("%s: total size (%d bytes) is approaching the limit of %d bytes", fldPath, totalSize, BlueprintMaxTotalSize))
+	}
+
+	return warnings, errs
+}
+
+// Return the total size (in bytes) of the given blueprint files; the size of a file is the length of its path plus
+// the length of its content.
+func BlueprintTotalSize(files map[string]string) int64 {
+	var size int64
+	for name, content := range files {
+		size += int64(len(name) + len(content))
+	}
+	return size
+}
+
+func validateBlueprintFilePath(name string) error {
+	if name == "" {
+		return fmt.Errorf("must not be empty")
+	}
+	if strings.ContainsFunc(name, func(r rune) bool { return r == '\\' || unicode.IsControl(r) }) {
+		return fmt.Errorf("must not contain backslashes or control characters")
+	}
+	// note: this must be at least as strict as the check in writeBlueprintFiles() in the generator package
+	if path.IsAbs(name) || name != path.Clean(name) || strings.Contains(name, "..") {
+		return fmt.Errorf("must be a clean relative path not containing '..'")
+	}
+	for segment := range strings.SplitSeq(name, "/") {
+		if len(segment) > blueprintMaxSegmentLength {
+			return fmt.Errorf("path segments must not be longer than %d characters", blueprintMaxSegmentLength)
+		}
+	}
+	base := path.Base(name)
+	if ext := strings.ToLower(path.Ext(base)); ext != "" && ext != base && slices.Contains(BlueprintAllowedExtensions, ext) {
+		return nil
+	}
+	if slices.Contains(BlueprintAllowedNames, base) || slices.Contains(BlueprintAllowedExtensions, strings.ToLower(base)) {
+		return nil
+	}
+	return fmt.Errorf("file type not allowed (allowed extensions: %s; allowed names: %s)", strings.Join(BlueprintAllowedExtensions, ", "), strings.Join(BlueprintAllowedNames, ", "))
+}
+
+func isText(content string) bool {
+	return utf8.ValidString(content) && !strings.ContainsRune(content, 0)
+}
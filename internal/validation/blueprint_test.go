@@ -0,0 +1,98 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validation
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/sap/component-operator/internal/testutil"
+)
+
+func TestValidateBlueprintFiles(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       map[string]string
+		expectedErr string
+	}{
+		{
+			name: "typical chart files",
+			files: map[string]string{
+				"Chart.yaml":                   "name: test\n",
+				"Chart.lock":                   "",
+				".helmignore":                  "",
+				".gitignore":                   "",
+				"Makefile":                     "all:\n",
+				"values.yaml":                  "",
+				"values.schema.json":           "{}",
+				"values.schema.v2.json":        "{}",
+				"templates/_helpers.tpl":       "",
+				"templates/NOTES.txt":          "",
+				"files/init.sh":                "#!/bin/sh\n",
+				"files/script.py":              "print()\n",
+				"files/config.xml":             "<a/>\n",
+				"files/data.csv":               "a,b\n",
+				"files/schema.sql":             "select 1;\n",
+				"files/ca.pem":                 "-----BEGIN CERTIFICATE-----\n",
+				"files/tls.crt":                "-----BEGIN CERTIFICATE-----\n",
+				"files/unicode-äöü.txt":        "äöü ✓\n",
+				"files/README":                 "",
+				"files/UPPER.YAML":             "",
+				"charts/dependency/LICENSE":    "",
+				"charts/dependency/Chart.yaml": "name: dependency\n",
+			},
+		},
+		{name: "empty path", files: map[string]string{"": ""}, expectedErr: "must not be empty"},
+		{name: "absolute path", files: map[string]string{"/Chart.yaml": ""}, expectedErr: "must be a clean relative path"},
+		{name: "parent path", files: map[string]string{"a/../Chart.yaml": ""}, expectedErr: "must be a clean relative path"},
+		{name: "unclean path", files: map[string]string{"a//Chart.yaml": ""}, expectedErr: "must be a clean relative path"},
+		{name: "backslash", files: map[string]string{`a\Chart.yaml`: ""}, expectedErr: "must not contain backslashes"},
+		{name: "long segment", files: map[string]string{strings.Repeat("a", 256): ""}, expectedErr: "path segments must not be longer than 255 characters"},
+		{name: "disallowed extension", files: map[string]string{"files/app.exe": ""}, expectedErr: "spec.files[files/app.exe]: Invalid value: \"files/app.exe\": file type not allowed"},
+		{name: "packaged chart", files: map[string]string{"charts/dependency-1.0.0.tgz": "x"}, expectedErr: "file type not allowed"},
+		{name: "backup file", files: map[string]string{"values.yaml.bak": ""}, expectedErr: "file type not allowed"},
+		{name: "unknown name", files: map[string]string{"files/data": ""}, expectedErr: "file type not allowed"},
+		{name: "extension only in directory", files: map[string]string{"templates.yaml/data": ""}, expectedErr: "file type not allowed"},
+		{name: "binary content", files: map[string]string{"logo.txt": "\x1f\x8b\x08\x00\xff"}, expectedErr: "binary content is not supported"},
+		{name: "nul character", files: map[string]string{"a.txt": "a\x00"}, expectedErr: "binary content is not supported"},
+		{name: "too large", files: map[string]string{"a.txt": strings.Repeat("x", BlueprintMaxTotalSize)}, expectedErr: "total size must not exceed"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, errs := ValidateBlueprintFiles(test.files, field.NewPath("spec", "files"))
+			err := errs.ToAggregate()
+			if testutil.CheckError(t, err, test.expectedErr) {
+				return
+			}
+		})
+	}
+}
+
+func TestValidateBlueprintFilesReportsErrorsPerFile(t *testing.T) {
+	files := map[string]string{
+		"Chart.yaml":     "name: test\n",
+		"files/app.exe":  "",
+		"files/a.tgz":    "",
+		"../values.yaml": "",
+	}
+	_, errs := ValidateBlueprintFiles(files, field.NewPath("spec", "files"))
+
+	var fields []string
+	for _, err := range errs {
+		if err.Type != field.ErrorTypeInvalid {
+			t.Errorf("expected error of type %s, got %s", field.ErrorTypeInvalid, err.Type)
+		}
+		fields = append(fields, err.Field)
+	}
+	expected := []string{"spec.files[../values.yaml]", "spec.files[files/a.tgz]", "spec.files[files/app.exe]"}
+	if !slices.Equal(fields, expected) {
+		t.Errorf("expected errors for %v, got %v", expected, fields)
+	}
+}
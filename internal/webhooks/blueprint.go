@@ -0,0 +1,46 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webhooks
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	operatorv1alpha1 "github.com/sap/component-operator/api/v1alpha1"
+	"github.com/sap/component-operator/internal/validation"
+)
+
+type blueprintWebhook struct{}
+
+var _ admission.Validator[*operatorv1alpha1.Blueprint] = &blueprintWebhook{}
+
+func (w *blueprintWebhook) ValidateCreate(ctx context.Context, blueprint *operatorv1alpha1.Blueprint) (admission.Warnings, error) {
+	return validateBlueprint(blueprint)
+}
+
+func (w *blueprintWebhook) ValidateUpdate(ctx context.Context, oldBlueprint *operatorv1alpha1.Blueprint, blueprint *operatorv1alpha1.Blueprint) (admission.Warnings, error) {
+	// note: see the according comment in componentWebhook.ValidateUpdate()
+	if !blueprint.DeletionTimestamp.IsZero() || equality.Semantic.DeepEqual(oldBlueprint.Spec, blueprint.Spec) {
+		return nil, nil
+	}
+	return validateBlueprint(blueprint)
+}
+
+func (w *blueprintWebhook) ValidateDelete(ctx context.Context, blueprint *operatorv1alpha1.Blueprint) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateBlueprint(blueprint *operatorv1alpha1.Blueprint) (admission.Warnings, error) {
+	warnings, errs := validation.ValidateBlueprintFiles(blueprint.Spec.Files, field.NewPath("spec", "files"))
+	if len(errs) > 0 {
+		return warnings, apierrors.NewInvalid(operatorv1alpha1.GroupVersion.WithKind(operatorv1alpha1.KindBlueprint).GroupKind(), blueprint.Name, errs)
+	}
+	return warnings, nil
+}
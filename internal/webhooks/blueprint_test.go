@@ -0,0 +1,78 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webhooks
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1alpha1 "github.com/sap/component-operator/api/v1alpha1"
+	"github.com/sap/component-operator/internal/validation"
+)
+
+func newTestBlueprint(files map[string]string) *operatorv1alpha1.Blueprint {
+	return &operatorv1alpha1.Blueprint{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "blueprint"},
+		Spec:       operatorv1alpha1.BlueprintSpec{Files: files},
+	}
+}
+
+func TestBlueprintValidateCreate(t *testing.T) {
+	tests := []struct {
+		name             string
+		files            map[string]string
+		expectedErr      string
+		expectedWarnings []string
+	}{
+		{name: "empty"},
+		{name: "valid", files: map[string]string{"Chart.yaml": "name: test\n", "templates/configmap.yaml": "kind: ConfigMap\n"}},
+		{name: "path escaping the blueprint", files: map[string]string{"../configmap.yaml": ""}, expectedErr: `spec.files[../configmap.yaml]: Invalid value`},
+		{name: "absolute path", files: map[string]string{"/configmap.yaml": ""}, expectedErr: `spec.files[/configmap.yaml]: Invalid value`},
+		{name: "binary content", files: map[string]string{"data.yaml": "a\x00b"}, expectedErr: "binary content is not supported"},
+		{name: "too large", files: map[string]string{"data.txt": strings.Repeat("x", validation.BlueprintMaxTotalSize)}, expectedErr: "total size must not exceed"},
+		{name: "approaching size limit", files: map[string]string{"data.txt": strings.Repeat("x", validation.BlueprintWarningTotalSize)}, expectedWarnings: []string{"approaching the limit"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			warnings, err := (&blueprintWebhook{}).ValidateCreate(context.Background(), newTestBlueprint(test.files))
+			checkValidationResult(t, warnings, err, test.expectedErr, test.expectedWarnings)
+		})
+	}
+}
+
+func TestBlueprintValidateUpdate(t *testing.T) {
+	valid := map[string]string{"Chart.yaml": "name: test\n"}
+	invalid := map[string]string{"../Chart.yaml": "name: test\n"}
+
+	tests := []struct {
+		name        string
+		oldFiles    map[string]string
+		files       map[string]string
+		deleting    bool
+		expectedErr string
+	}{
+		{name: "valid change", oldFiles: invalid, files: valid},
+		{name: "invalid change", oldFiles: valid, files: invalid, expectedErr: "spec.files[../Chart.yaml]: Invalid value"},
+		{name: "unchanged invalid spec", oldFiles: invalid, files: invalid},
+		{name: "deleting with invalid change", oldFiles: valid, files: invalid, deleting: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			blueprint := newTestBlueprint(test.files)
+			blueprint.Finalizers = []string{"test"}
+			if test.deleting {
+				blueprint.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+			}
+			warnings, err := (&blueprintWebhook{}).ValidateUpdate(context.Background(), newTestBlueprint(test.oldFiles), blueprint)
+			checkValidationResult(t, warnings, err, test.expectedErr, nil)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	operatorv1alpha1 "github.com/sap/component-operator/api/v1alpha1"
+)
+
+const (
+	decryptionProviderSops = "sops"
+)
+
+type componentWebhook struct{}
+
+var _ admission.Defaulter[*operatorv1alpha1.Component] = &componentWebhook{}
+var _ admission.Validator[*operatorv1alpha1.Component] = &componentWebhook{}
+
+func (w *componentWebhook) Default(ctx context.Context, component *operatorv1alpha1.Component) error {
+	if component.Spec.Decryption != nil && component.Spec.Decryption.Provider == "" {
+		component.Spec.Decryption.Provider = decryptionProviderSops
+	}
+	return nil
+}
+
+func (w *componentWebhook) ValidateCreate(ctx context.Context, component *operatorv1alpha1.Component) (admission.Warnings, error) {
+	return validateComponent(component)
+}
+
+func (w *componentWebhook) ValidateUpdate(ctx context.Context, oldComponent *operatorv1alpha1.Component, component *operatorv1alpha1.Component) (admission.Warnings, error) {
+	// note: updates not touching the spec (such as finalizer changes) must always pass, since otherwise components which
+	// became invalid by a stricter validation could no longer be processed (or deleted);
+	// note: equality.Semantic.DeepEqual() cannot be used here, since it panics on the unexported fields of the spec (such as in SourceReference)
+	if !component.DeletionTimestamp.IsZero() || reflect.DeepEqual(oldComponent.Spec, component.Spec) {
+		return nil, nil
+	}
+	return validateComponent(component)
+}
+
+func (w *componentWebhook) ValidateDelete(ctx context.Context, component *operatorv1alpha1.Component) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateComponent(component *operatorv1alpha1.Component) (admission.Warnings, error) {
+	var warnings admission.Warnings
+	var errs field.ErrorList
+
+	specPath := field.NewPath("spec")
+
+	if component.Spec.Path != "" && !filepath.IsLocal(component.Spec.Path) {
+		errs = append(errs, field.Invalid(specPath.Child("path"), component.Spec.Path, "must be a relative path not escaping the source artifact"))
+	}
+
+	if component.Spec.Values != nil {
+		var values map[string]any
+		if err := json.Unmarshal(component.Spec.Values.Raw, &values); err != nil || values == nil {
+			errs = append(errs, field.Invalid(specPath.Child("values"), string(component.Spec.Values.Raw), "must be a JSON object"))
+		}
+	}
+
+	if decryption := component.Spec.Decryption; decryption != nil && decryption.Provider != decryptionProviderSops {
+		errs = append(errs, field.NotSupported(specPath.Child("decryption", "provider"), decryption.Provider, []string{decryptionProviderSops}))
+	}
+
+	if oci := component.Spec.SourceRef.OciRepository; oci != nil && oci.Insecure {
+		warnings = append(warnings, "spec.sourceRef.ociRepository.insecure: artifacts are fetched over an insecure connection")
+	}
+
+	for _, name := range sourceNamespacedNames(&component.Spec.SourceRef) {
+		if name.Namespace != "" && name.Namespace != component.Namespace {
+			warnings = append(warnings, fmt.Sprintf("spec.sourceRef: source %s resides in another namespace (may be rejected if cross-namespace references are disabled)", name))
+		}
+	}
+
+	for i, dependency := range component.Spec.Dependencies {
+		name := dependency.WithDefaultNamespace(component.Namespace)
+		if dependency.IsComponent() && name.Namespace == component.Namespace && name.Name == component.Name {
+			errs = append(errs, field.Invalid(specPath.Child("dependencies").Index(i), name.String(), "component must not depend on itself"))
+		}
+		if name.Namespace != component.Namespace {
+			warnings = append(warnings, fmt.Sprintf("spec.dependencies[%d]: dependency %s resides in another namespace (may be rejected if cross-namespace references are disabled)", i, name))
+		}
+	}
+
+	if teardown := component.Spec.Teardown; teardown != nil && teardown.Mode == operatorv1alpha1.TeardownModeCascade {
+		warnings = append(warnings, "spec.teardown.mode: deleting this component will also delete all components (transitively) depending on it (in other namespaces only if they grant references from this namespace)")
+	}
+
+	if len(errs) > 0 {
+		return warnings, apierrors.NewInvalid(operatorv1alpha1.GroupVersion.WithKind(operatorv1alpha1.KindComponent).GroupKind(), component.Name, errs)
+	}
+	return warnings, nil
+}
+
+// return the names of the objects referenced by the given source reference (if any)
+func sourceNamespacedNames(sourceRef *operatorv1alpha1.SourceReference) []operatorv1alpha1.NamespacedName {
+	switch {
+	case sourceRef.Blueprint != nil:
+		return []operatorv1alpha1.NamespacedName{sourceRef.Blueprint.NamespacedName}
+	case sourceRef.ConfigMap != nil:
+		return []operatorv1alpha1.NamespacedName{sourceRef.ConfigMap.NamespacedName}
+	case sourceRef.Secret != nil:
+		return []operatorv1alpha1.NamespacedName{sourceRef.Secret.NamespacedName}
+	case sourceRef.FluxGitRepository != nil:
+		return []operatorv1alpha1.NamespacedName{sourceRef.FluxGitRepository.NamespacedName}
+	case sourceRef.FluxOciRepository != nil:
+		return []operatorv1alpha1.NamespacedName{sourceRef.FluxOciRepository.NamespacedName}
+	case sourceRef.FluxBucket != nil:
+		return []operatorv1alpha1.NamespacedName{sourceRef.FluxBucket.NamespacedName}
+	case sourceRef.FluxHelmChart != nil:
+		return []operatorv1alpha1.NamespacedName{sourceRef.FluxHelmChart.NamespacedName}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,217 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webhooks
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	operatorv1alpha1 "github.com/sap/component-operator/api/v1alpha1"
+	"github.com/sap/component-operator/internal/testutil"
+)
+
+func newTestComponent(modify func(spec *operatorv1alpha1.ComponentSpec)) *operatorv1alpha1.Component {
+	component := &operatorv1alpha1.Component{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "component"},
+		Spec: operatorv1alpha1.ComponentSpec{
+			SourceRef: operatorv1alpha1.SourceReference{
+				HttpRepository: &operatorv1alpha1.HttpRepository{Url: "https://example.com/artifact.tar.gz"},
+			},
+		},
+	}
+	if modify != nil {
+		modify(&component.Spec)
+	}
+	return component
+}
+
+// check the result of a validation against the expected error and warnings (given as substrings)
+func checkValidationResult(t *testing.T, warnings admission.Warnings, err error, expectedErr string, expectedWarnings []string) {
+	t.Helper()
+	testutil.CheckError(t, err, expectedErr)
+	if len(warnings) != len(expectedWarnings) {
+		t.Fatalf("expected %d warning(s), got %q", len(expectedWarnings), warnings)
+	}
+	for i, expected := range expectedWarnings {
+		if !strings.Contains(warnings[i], expected) {
+			t.Errorf("expected warning containing %q, got %q", expected, warnings[i])
+		}
+	}
+}
+
+func TestComponentDefault(t *testing.T) {
+	tests := []struct {
+		name       string
+		decryption *operatorv1alpha1.Decryption
+		expected   *operatorv1alpha1.Decryption
+	}{
+		{name: "no decryption"},
+		{name: "empty provider", decryption: &operatorv1alpha1.Decryption{}, expected: &operatorv1alpha1.Decryption{Provider: "sops"}},
+		{name: "explicit provider", decryption: &operatorv1alpha1.Decryption{Provider: "other"}, expected: &operatorv1alpha1.Decryption{Provider: "other"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			component := newTestComponent(func(spec *operatorv1alpha1.ComponentSpec) {
+				spec.Decryption = test.decryption
+			})
+			if err := (&componentWebhook{}).Default(context.Background(), component); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if decryption := component.Spec.Decryption; !reflect.DeepEqual(decryption, test.expected) {
+				t.Errorf("expected decryption %v, got %v", test.expected, decryption)
+			}
+		})
+	}
+}
+
+func TestComponentValidateCreate(t *testing.T) {
+	tests := []struct {
+		name             string
+		modify           func(spec *operatorv1alpha1.ComponentSpec)
+		expectedErr      string
+		expectedWarnings []string
+	}{
+		{name: "valid"},
+		{
+			name: "valid with path and values",
+			modify: func(spec *operatorv1alpha1.ComponentSpec) {
+				spec.Path = "charts/app"
+				spec.Values = &apiextensionsv1.JSON{Raw: []byte(`{"a":1}`)}
+			},
+		},
+		{
+			name:        "absolute path",
+			modify:      func(spec *operatorv1alpha1.ComponentSpec) { spec.Path = "/charts/app" },
+			expectedErr: "spec.path: Invalid value",
+		},
+		{
+			name:        "path escaping the artifact",
+			modify:      func(spec *operatorv1alpha1.ComponentSpec) { spec.Path = "charts/../../app" },
+			expectedErr: "spec.path: Invalid value",
+		},
+		{
+			name:        "values not an object",
+			modify:      func(spec *operatorv1alpha1.ComponentSpec) { spec.Values = &apiextensionsv1.JSON{Raw: []byte(`[1]`)} },
+			expectedErr: "spec.values: Invalid value",
+		},
+		{
+			name:        "null values",
+			modify:      func(spec *operatorv1alpha1.ComponentSpec) { spec.Values = &apiextensionsv1.JSON{Raw: []byte(`null`)} },
+			expectedErr: "spec.values: Invalid value",
+		},
+		{
+			name: "unsupported decryption provider",
+			modify: func(spec *operatorv1alpha1.ComponentSpec) {
+				spec.Decryption = &operatorv1alpha1.Decryption{Provider: "other"}
+			},
+			expectedErr: "spec.decryption.provider: Unsupported value",
+		},
+		{
+			name: "dependency on itself",
+			modify: func(spec *operatorv1alpha1.ComponentSpec) {
+				spec.Dependencies = []operatorv1alpha1.Dependency{{NamespacedName: operatorv1alpha1.NamespacedName{Name: "component"}}}
+			},
+			expectedErr: "component must not depend on itself",
+		},
+		{
+			name: "dependency on object with same name",
+			modify: func(spec *operatorv1alpha1.ComponentSpec) {
+				spec.Dependencies = []operatorv1alpha1.Dependency{{NamespacedName: operatorv1alpha1.NamespacedName{Name: "component"}, ApiVersion: "v1", Kind: "ConfigMap"}}
+			},
+		},
+		{
+			name: "insecure oci repository",
+			modify: func(spec *operatorv1alpha1.ComponentSpec) {
+				spec.SourceRef = operatorv1alpha1.SourceReference{OciRepository: &operatorv1alpha1.OciRepository{Url: "oci://registry/repo", Insecure: true}}
+			},
+			expectedWarnings: []string{"insecure connection"},
+		},
+		{
+			name: "source in other namespace",
+			modify: func(spec *operatorv1alpha1.ComponentSpec) {
+				spec.SourceRef = operatorv1alpha1.SourceReference{Blueprint: &operatorv1alpha1.BlueprintReference{NamespacedName: operatorv1alpha1.NamespacedName{Namespace: "other", Name: "blueprint"}}}
+			},
+			expectedWarnings: []string{"spec.sourceRef: source other/blueprint resides in another namespace"},
+		},
+		{
+			name: "source in same namespace",
+			modify: func(spec *operatorv1alpha1.ComponentSpec) {
+				spec.SourceRef = operatorv1alpha1.SourceReference{FluxGitRepository: &operatorv1alpha1.FluxGitRepositoryReference{NamespacedName: operatorv1alpha1.NamespacedName{Namespace: "test", Name: "source"}}}
+			},
+		},
+		{
+			name: "dependency in other namespace",
+			modify: func(spec *operatorv1alpha1.ComponentSpec) {
+				spec.Dependencies = []operatorv1alpha1.Dependency{
+					{NamespacedName: operatorv1alpha1.NamespacedName{Name: "a"}},
+					{NamespacedName: operatorv1alpha1.NamespacedName{Namespace: "other", Name: "b"}},
+				}
+			},
+			expectedWarnings: []string{"spec.dependencies[1]: dependency other/b resides in another namespace"},
+		},
+		{
+			name: "cascading teardown",
+			modify: func(spec *operatorv1alpha1.ComponentSpec) {
+				spec.Teardown = &operatorv1alpha1.Teardown{Mode: operatorv1alpha1.TeardownModeCascade}
+			},
+			expectedWarnings: []string{"spec.teardown.mode"},
+		},
+		{
+			name: "errors and warnings",
+			modify: func(spec *operatorv1alpha1.ComponentSpec) {
+				spec.Path = "../app"
+				spec.Dependencies = []operatorv1alpha1.Dependency{{NamespacedName: operatorv1alpha1.NamespacedName{Namespace: "other", Name: "a"}}}
+			},
+			expectedErr:      "spec.path: Invalid value",
+			expectedWarnings: []string{"spec.dependencies[0]"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			warnings, err := (&componentWebhook{}).ValidateCreate(context.Background(), newTestComponent(test.modify))
+			checkValidationResult(t, warnings, err, test.expectedErr, test.expectedWarnings)
+		})
+	}
+}
+
+func TestComponentValidateUpdate(t *testing.T) {
+	invalid := func(spec *operatorv1alpha1.ComponentSpec) { spec.Path = "/app" }
+
+	tests := []struct {
+		name        string
+		oldModify   func(spec *operatorv1alpha1.ComponentSpec)
+		modify      func(spec *operatorv1alpha1.ComponentSpec)
+		deleting    bool
+		expectedErr string
+	}{
+		{name: "valid change", modify: func(spec *operatorv1alpha1.ComponentSpec) { spec.Path = "app" }},
+		{name: "invalid change", modify: invalid, expectedErr: "spec.path: Invalid value"},
+		{name: "unchanged invalid spec", oldModify: invalid, modify: invalid},
+		{name: "deleting with invalid change", modify: invalid, deleting: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			oldComponent := newTestComponent(test.oldModify)
+			component := newTestComponent(test.modify)
+			// note: updates not touching the spec (here: adding a finalizer) must pass, even if the spec is invalid
+			component.Finalizers = []string{"test"}
+			if test.deleting {
+				component.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+			}
+			warnings, err := (&componentWebhook{}).ValidateUpdate(context.Background(), oldComponent, component)
+			checkValidationResult(t, warnings, err, test.expectedErr, nil)
+		})
+	}
+}
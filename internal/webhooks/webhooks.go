@@ -0,0 +1,32 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webhooks
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	operatorv1alpha1 "github.com/sap/component-operator/api/v1alpha1"
+)
+
+// Register the admission webhooks for components and blueprints with the webhook server of the given manager.
+// The webhooks are served under the default paths generated by controller-runtime, such as
+// /validate-core-cs-sap-com-v1alpha1-component and /mutate-core-cs-sap-com-v1alpha1-component.
+func SetupWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewWebhookManagedBy(mgr, &operatorv1alpha1.Component{}).
+		WithDefaulter(&componentWebhook{}).
+		WithValidator(&componentWebhook{}).
+		Complete(); err != nil {
+		return err
+	}
+
+	if err := ctrl.NewWebhookManagedBy(mgr, &operatorv1alpha1.Blueprint{}).
+		WithValidator(&blueprintWebhook{}).
+		Complete(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,281 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	operatorv1alpha1 "github.com/sap/component-operator/api/v1alpha1"
+	"github.com/sap/component-operator/internal/testutil"
+)
+
+const (
+	mutateComponentWebhook   = "mutate.components.core.cs.sap.com"
+	validateComponentWebhook = "validate.components.core.cs.sap.com"
+	validateBlueprintWebhook = "validate.blueprints.core.cs.sap.com"
+)
+
+var chartWebhookPattern = regexp.MustCompile(`(?s)\n- name: (\S+)\n.*?\n\s+path: (\S+)\n`)
+
+// read the webhook paths from the chart, by webhook name
+func readChartWebhookPaths(t *testing.T) map[string]string {
+	t.Helper()
+	data, err := os.ReadFile("../../chart/templates/webhook.yaml")
+	if err != nil {
+		t.Fatalf("error reading chart webhook template: %s", err)
+	}
+	paths := make(map[string]string)
+	for _, match := range chartWebhookPattern.FindAllStringSubmatch(string(data), -1) {
+		paths[match[1]] = match[2]
+	}
+	for _, name := range []string{mutateComponentWebhook, validateComponentWebhook, validateBlueprintWebhook} {
+		if _, ok := paths[name]; !ok {
+			t.Fatalf("webhook %s not found in chart", name)
+		}
+	}
+	if len(paths) != 3 {
+		t.Fatalf("expected 3 webhooks in chart, got %v", paths)
+	}
+	return paths
+}
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := operatorv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return scheme
+}
+
+func TestWebhookPathsMatchChart(t *testing.T) {
+	// note: the manager is never started, so the api server does not need to exist
+	mgr, err := ctrl.NewManager(&rest.Config{Host: "https://127.0.0.1:1"}, ctrl.Options{
+		Scheme:  newTestScheme(t),
+		Metrics: metricsserver.Options{BindAddress: "0"},
+	})
+	if err != nil {
+		t.Fatalf("error creating manager: %s", err)
+	}
+	if err := SetupWithManager(mgr); err != nil {
+		t.Fatalf("error setting up webhooks: %s", err)
+	}
+
+	mux := mgr.GetWebhookServer().WebhookMux()
+	for name, path := range readChartWebhookPaths(t) {
+		if _, pattern := mux.Handler(httptest.NewRequest(http.MethodPost, path, nil)); pattern != path {
+			t.Errorf("path %s of webhook %s in chart is not served by the webhook server", path, name)
+		}
+	}
+}
+
+func TestWebhookAdmission(t *testing.T) {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		t.Skip("KUBEBUILDER_ASSETS not set; run make envtest to install the envtest binaries")
+	}
+
+	paths := readChartWebhookPaths(t)
+	failurePolicy := admissionregistrationv1.Fail
+	sideEffects := admissionregistrationv1.SideEffectClassNone
+	newWebhookClientConfig := func(name string) admissionregistrationv1.WebhookClientConfig {
+		// note: envtest rewrites the service reference into an url, and adds the leading slash of the path itself
+		return admissionregistrationv1.WebhookClientConfig{
+			Service: &admissionregistrationv1.ServiceReference{Path: new(strings.TrimPrefix(paths[name], "/"))},
+		}
+	}
+	newRules := func(resource string) []admissionregistrationv1.RuleWithOperations {
+		return []admissionregistrationv1.RuleWithOperations{{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{operatorv1alpha1.GroupVersion.Group},
+				APIVersions: []string{operatorv1alpha1.GroupVersion.Version},
+				Resources:   []string{resource},
+			},
+		}}
+	}
+
+	env := &envtest.Environment{
+		CRDDirectoryPaths:     []string{"../../crds"},
+		ErrorIfCRDPathMissing: true,
+		WebhookInstallOptions: envtest.WebhookInstallOptions{
+			MutatingWebhooks: []*admissionregistrationv1.MutatingWebhookConfiguration{{
+				ObjectMeta: metav1.ObjectMeta{Name: "component-operator"},
+				Webhooks: []admissionregistrationv1.MutatingWebhook{{
+					Name:                    mutateComponentWebhook,
+					AdmissionReviewVersions: []string{"v1"},
+					ClientConfig:            newWebhookClientConfig(mutateComponentWebhook),
+					Rules:                   newRules("components"),
+					FailurePolicy:           &failurePolicy,
+					SideEffects:             &sideEffects,
+				}},
+			}},
+			ValidatingWebhooks: []*admissionregistrationv1.ValidatingWebhookConfiguration{{
+				ObjectMeta: metav1.ObjectMeta{Name: "component-operator"},
+				Webhooks: []admissionregistrationv1.ValidatingWebhook{
+					{
+						Name:                    validateComponentWebhook,
+						AdmissionReviewVersions: []string{"v1"},
+						ClientConfig:            newWebhookClientConfig(validateComponentWebhook),
+						Rules:                   newRules("components"),
+						FailurePolicy:           &failurePolicy,
+						SideEffects:             &sideEffects,
+					},
+					{
+						Name:                    validateBlueprintWebhook,
+						AdmissionReviewVersions: []string{"v1"},
+						ClientConfig:            newWebhookClientConfig(validateBlueprintWebhook),
+						Rules:                   newRules("blueprints"),
+						FailurePolicy:           &failurePolicy,
+						SideEffects:             &sideEffects,
+					},
+				},
+			}},
+		},
+	}
+	cfg, err := env.Start()
+	if err != nil {
+		t.Fatalf("error starting test environment: %s", err)
+	}
+	t.Cleanup(func() {
+		if err := env.Stop(); err != nil {
+			t.Errorf("error stopping test environment: %s", err)
+		}
+	})
+
+	scheme := newTestScheme(t)
+	options := &env.WebhookInstallOptions
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:  scheme,
+		Metrics: metricsserver.Options{BindAddress: "0"},
+		WebhookServer: webhook.NewServer(webhook.Options{
+			Host:    options.LocalServingHost,
+			Port:    options.LocalServingPort,
+			CertDir: options.LocalServingCertDir,
+		}),
+	})
+	if err != nil {
+		t.Fatalf("error creating manager: %s", err)
+	}
+	if err := SetupWithManager(mgr); err != nil {
+		t.Fatalf("error setting up webhooks: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- mgr.Start(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("error running manager: %s", err)
+		}
+	})
+
+	started := mgr.GetWebhookServer().StartedChecker()
+	for deadline := time.Now().Add(30 * time.Second); started(nil) != nil; time.Sleep(100 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("timeout waiting for the webhook server to start")
+		}
+	}
+
+	clnt, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		t.Fatalf("error creating client: %s", err)
+	}
+
+	t.Run("component defaulting", func(t *testing.T) {
+		component := newTestComponent(func(spec *operatorv1alpha1.ComponentSpec) {
+			spec.Decryption = &operatorv1alpha1.Decryption{}
+		})
+		component.Namespace = "default"
+		component.Name = "defaulted"
+		if err := clnt.Create(ctx, component); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if component.Spec.Decryption == nil || component.Spec.Decryption.Provider != "sops" {
+			t.Errorf("expected decryption provider sops, got %v", component.Spec.Decryption)
+		}
+	})
+
+	componentTests := []struct {
+		name        string
+		modify      func(spec *operatorv1alpha1.ComponentSpec)
+		expectedErr string
+	}{
+		{name: "valid-component"},
+		{
+			name:        "path-escaping-the-artifact",
+			modify:      func(spec *operatorv1alpha1.ComponentSpec) { spec.Path = "charts/../../app" },
+			expectedErr: "spec.path: Invalid value",
+		},
+		{
+			name: "dependency-on-itself",
+			modify: func(spec *operatorv1alpha1.ComponentSpec) {
+				spec.Dependencies = []operatorv1alpha1.Dependency{{NamespacedName: operatorv1alpha1.NamespacedName{Name: "dependency-on-itself"}}}
+			},
+			expectedErr: "component must not depend on itself",
+		},
+	}
+	for _, test := range componentTests {
+		t.Run(test.name, func(t *testing.T) {
+			component := newTestComponent(test.modify)
+			component.Namespace = "default"
+			component.Name = test.name
+			testutil.CheckError(t, clnt.Create(ctx, component), test.expectedErr)
+		})
+	}
+
+	blueprintTests := []struct {
+		name        string
+		files       map[string]string
+		expectedErr string
+	}{
+		{name: "valid-blueprint", files: map[string]string{"Chart.yaml": "name: test\n", "templates/configmap.yaml": "kind: ConfigMap\n"}},
+		{name: "path-escaping-the-blueprint", files: map[string]string{"../configmap.yaml": ""}, expectedErr: "spec.files[../configmap.yaml]"},
+		{name: "binary-content", files: map[string]string{"data.yaml": "a\x00b"}, expectedErr: "binary content is not supported"},
+	}
+	for _, test := range blueprintTests {
+		t.Run(test.name, func(t *testing.T) {
+			blueprint := newTestBlueprint(test.files)
+			blueprint.Namespace = "default"
+			blueprint.Name = test.name
+			testutil.CheckError(t, clnt.Create(ctx, blueprint), test.expectedErr)
+		})
+	}
+
+	t.Run("invalid update", func(t *testing.T) {
+		blueprint := newTestBlueprint(map[string]string{"Chart.yaml": "name: test\n"})
+		blueprint.Namespace = "default"
+		blueprint.Name = "updated-blueprint"
+		if err := clnt.Create(ctx, blueprint); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		blueprint.Spec.Files["../Chart.yaml"] = "name: test\n"
+		testutil.CheckError(t, clnt.Update(ctx, blueprint), "spec.files[../Chart.yaml]")
+	})
+}
@@ -7,7 +7,9 @@ package main
 
 import (
 	"flag"
+	"net"
 	"os"
+	"strconv"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -15,6 +17,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/sap/component-operator/pkg/operator"
 )
@@ -31,12 +34,18 @@ func init() {
 func main() {
 	var metricsAddr string
 	var probeAddr string
+	var webhookAddr string
+	var webhookCertDir string
 	var enableLeaderElection bool
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080",
 		"The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081",
 		"The address the probe endpoint binds to.")
+	flag.StringVar(&webhookAddr, "webhook-bind-address", ":9443",
+		"The address the webhooks endpoint binds to (only used if webhooks are enabled).")
+	flag.StringVar(&webhookCertDir, "webhook-tls-directory", "",
+		"The directory containing the webhook server key and certificate, as tls.key and tls.crt; defaults to $TMPDIR/k8s-webhook-server/serving-certs.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
 	operator.InitFlags(flag.CommandLine)
@@ -53,6 +62,12 @@ func main() {
 		os.Exit(1)
 	}
 
+	webhookHost, webhookPort, err := parseAddress(webhookAddr)
+	if err != nil {
+		setupLog.Error(err, "error parsing webhook bind address")
+		os.Exit(1)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
 		Client: client.Options{
@@ -67,6 +82,15 @@ func main() {
 			BindAddress: metricsAddr,
 		},
 		HealthProbeBindAddress: probeAddr,
+		// note: the base context carries settings evaluated by the api types (such as the reference policy)
+		BaseContext: operator.BaseContext,
+		// note: the webhook server is only started if webhooks are registered (see flag --enable-webhooks);
+		// certificates are reloaded automatically when they change on disk
+		WebhookServer: webhook.NewServer(webhook.Options{
+			Host:    webhookHost,
+			Port:    webhookPort,
+			CertDir: webhookCertDir,
+		}),
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -93,3 +117,15 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+func parseAddress(address string) (string, int, error) {
+	host, p, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", -1, err
+	}
+	port, err := strconv.Atoi(p)
+	if err != nil {
+		return "", -1, err
+	}
+	return host, port, nil
+}
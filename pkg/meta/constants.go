@@ -8,3 +8,10 @@ package meta
 const (
 	Name = "component-operator.cs.sap.com"
 )
+
+const (
+	// Annotation on objects which may be referenced from other namespaces (such as blueprints, flux sources or components),
+	// granting access to the listed namespaces if cross-namespace references are disabled; the value is a comma-separated
+	// list of namespaces, where * grants access to all namespaces.
+	AnnotationKeyReferenceGrant = Name + "/allow-references-from"
+)
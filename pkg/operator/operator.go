@@ -6,7 +6,11 @@ SPDX-License-Identifier: Apache-2.0
 package operator
 
 import (
+	"context"
 	"flag"
+	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -24,7 +28,11 @@ import (
 	componentcache "github.com/sap/component-operator/internal/cache/component"
 	blueprintcontroller "github.com/sap/component-operator/internal/controllers/blueprint"
 	componentcontroller "github.com/sap/component-operator/internal/controllers/component"
+	"github.com/sap/component-operator/internal/gitrepository"
 	"github.com/sap/component-operator/internal/httprepository"
+	"github.com/sap/component-operator/internal/ocirepository"
+	"github.com/sap/component-operator/internal/reference"
+	"github.com/sap/component-operator/internal/webhooks"
 	"github.com/sap/component-operator/pkg/meta"
 )
 
@@ -33,14 +41,29 @@ import (
 
 const (
 	MaxConcurrentReconciles = 5
+	ArtifactCacheSize       = 1024 * 1024 * 1024
+	ArchiveMaxSize          = 512 * 1024 * 1024
+	ArchiveMaxEntries       = 50000
+	ArchiveMaxFileSize      = 64 * 1024 * 1024
+	GeneratorCacheTTL       = 60 * time.Minute
 )
 
 type Options struct {
-	Name                    string
-	DefaultServiceAccount   string
-	MaxConcurrentReconciles int
-	EventsAddress           string
-	FlagPrefix              string
+	Name                     string
+	DefaultServiceAccount    string
+	MaxConcurrentReconciles  int
+	EventsAddress            string
+	ArtifactCacheDirectory   string
+	ArtifactCacheSize        int64
+	ArchiveMaxSize           int64
+	ArchiveMaxEntries        int
+	ArchiveMaxFileSize       int64
+	GeneratorCacheTTL        time.Duration
+	GeneratorCacheMaxEntries int
+	GeneratorCacheMaxSize    int64
+	NoCrossNamespaceRefs     bool
+	EnableWebhooks           bool
+	FlagPrefix               string
 }
 
 type Operator struct {
@@ -73,6 +96,10 @@ func Setup(mgr ctrl.Manager) error {
 	return defaultOperator.Setup(mgr)
 }
 
+func BaseContext() context.Context {
+	return defaultOperator.(*Operator).BaseContext()
+}
+
 func New() *Operator {
 	return NewWithOptions(Options{})
 }
@@ -85,6 +112,26 @@ func NewWithOptions(options Options) *Operator {
 	if operator.options.MaxConcurrentReconciles == 0 {
 		operator.options.MaxConcurrentReconciles = MaxConcurrentReconciles
 	}
+	if operator.options.ArtifactCacheDirectory == "" {
+		operator.options.ArtifactCacheDirectory = filepath.Join(os.TempDir(), operator.options.Name, "artifacts")
+	}
+	if operator.options.ArtifactCacheSize == 0 {
+		operator.options.ArtifactCacheSize = ArtifactCacheSize
+	}
+	// note: zero archive limits in the options select the defaults; the limits can be disabled by setting the
+	// according flags to zero
+	if operator.options.ArchiveMaxSize == 0 {
+		operator.options.ArchiveMaxSize = ArchiveMaxSize
+	}
+	if operator.options.ArchiveMaxEntries == 0 {
+		operator.options.ArchiveMaxEntries = ArchiveMaxEntries
+	}
+	if operator.options.ArchiveMaxFileSize == 0 {
+		operator.options.ArchiveMaxFileSize = ArchiveMaxFileSize
+	}
+	if operator.options.GeneratorCacheTTL == 0 {
+		operator.options.GeneratorCacheTTL = GeneratorCacheTTL
+	}
 	return operator
 }
 
@@ -102,12 +149,49 @@ func (o *Operator) InitFlags(flagset *flag.FlagSet) {
 	flagset.StringVar(&o.options.DefaultServiceAccount, "default-service-account", o.options.DefaultServiceAccount, "Default service account name")
 	flagset.IntVar(&o.options.MaxConcurrentReconciles, "max-concurrent-reconciles", o.options.MaxConcurrentReconciles, "Maximum number of concurrent reconciler workers")
 	flagset.StringVar(&o.options.EventsAddress, "events-address", o.options.EventsAddress, "Address of the events receiver")
+	flagset.StringVar(&o.options.ArtifactCacheDirectory, "artifact-cache-directory", o.options.ArtifactCacheDirectory, "Directory used to cache downloaded source artifacts")
+	flagset.Int64Var(&o.options.ArtifactCacheSize, "artifact-cache-size", o.options.ArtifactCacheSize, "Maximum size (in bytes) of the artifact cache")
+	flagset.Int64Var(&o.options.ArchiveMaxSize, "archive-max-size", o.options.ArchiveMaxSize, "Maximum total (uncompressed) size (in bytes) of downloaded source archives (0 means no limit)")
+	flagset.IntVar(&o.options.ArchiveMaxEntries, "archive-max-entries", o.options.ArchiveMaxEntries, "Maximum number of entries in downloaded source archives (0 means no limit)")
+	flagset.Int64Var(&o.options.ArchiveMaxFileSize, "archive-max-file-size", o.options.ArchiveMaxFileSize, "Maximum (uncompressed) size (in bytes) of a single file in downloaded source archives (0 means no limit)")
+	flagset.DurationVar(&o.options.GeneratorCacheTTL, "generator-cache-ttl", o.options.GeneratorCacheTTL, "Time after which unused generators are evicted from the generator cache")
+	flagset.IntVar(&o.options.GeneratorCacheMaxEntries, "generator-cache-max-entries", o.options.GeneratorCacheMaxEntries, "Maximum number of entries in the generator cache (0 means no limit)")
+	flagset.Int64Var(&o.options.GeneratorCacheMaxSize, "generator-cache-max-size", o.options.GeneratorCacheMaxSize, "Approximate maximum size (in bytes) of the entries in the generator cache (0 means no limit)")
+	flagset.BoolVar(&o.options.NoCrossNamespaceRefs, "no-cross-namespace-refs", o.options.NoCrossNamespaceRefs, "Disallow references to objects in other namespaces, unless granted by the referenced object")
+	flagset.BoolVar(&o.options.EnableWebhooks, "enable-webhooks", o.options.EnableWebhooks, "Serve the validating and defaulting admission webhooks for components and blueprints")
 }
 
 func (o *Operator) ValidateFlags() error {
+	if o.options.ArtifactCacheSize < 0 {
+		return errors.New("invalid value for flag --artifact-cache-size: must not be negative")
+	}
+	if o.options.ArchiveMaxSize < 0 {
+		return errors.New("invalid value for flag --archive-max-size: must not be negative")
+	}
+	if o.options.ArchiveMaxEntries < 0 {
+		return errors.New("invalid value for flag --archive-max-entries: must not be negative")
+	}
+	if o.options.ArchiveMaxFileSize < 0 {
+		return errors.New("invalid value for flag --archive-max-file-size: must not be negative")
+	}
+	if o.options.GeneratorCacheTTL <= 0 {
+		return errors.New("invalid value for flag --generator-cache-ttl: must be positive")
+	}
+	if o.options.GeneratorCacheMaxEntries < 0 {
+		return errors.New("invalid value for flag --generator-cache-max-entries: must not be negative")
+	}
+	if o.options.GeneratorCacheMaxSize < 0 {
+		return errors.New("invalid value for flag --generator-cache-max-size: must not be negative")
+	}
 	return nil
 }
 
+// Return the context to be used as base context of the manager (see ctrl.Options); it carries the reference policy,
+// which is evaluated when the references of components are loaded (before the referenced objects are read).
+func (o *Operator) BaseContext() context.Context {
+	return reference.NewContext(context.Background(), reference.Policy{NoCrossNamespaceRefs: o.options.NoCrossNamespaceRefs})
+}
+
 func (o *Operator) GetUncacheableTypes() []client.Object {
 	return []client.Object{&operatorv1alpha1.Component{}, &operatorv1alpha1.Blueprint{}, &operatorv1alpha1.BlueprintVersion{}}
 }
@@ -118,10 +202,19 @@ func (o *Operator) Setup(mgr ctrl.Manager) error {
 	}
 
 	componentReconciler, err := componentcontroller.SetupWithManager(mgr, componentcontroller.ReconcilerOptions{
-		Name:                    o.options.Name,
-		DefaultServiceAccount:   o.options.DefaultServiceAccount,
-		MaxConcurrentReconciles: o.options.MaxConcurrentReconciles,
-		EventsAddress:           o.options.EventsAddress,
+		Name:                     o.options.Name,
+		DefaultServiceAccount:    o.options.DefaultServiceAccount,
+		MaxConcurrentReconciles:  o.options.MaxConcurrentReconciles,
+		EventsAddress:            o.options.EventsAddress,
+		ArtifactCacheDirectory:   o.options.ArtifactCacheDirectory,
+		ArtifactCacheSize:        o.options.ArtifactCacheSize,
+		ArchiveMaxSize:           o.options.ArchiveMaxSize,
+		ArchiveMaxEntries:        o.options.ArchiveMaxEntries,
+		ArchiveMaxFileSize:       o.options.ArchiveMaxFileSize,
+		GeneratorCacheTTL:        o.options.GeneratorCacheTTL,
+		GeneratorCacheMaxEntries: o.options.GeneratorCacheMaxEntries,
+		GeneratorCacheMaxSize:    o.options.GeneratorCacheMaxSize,
+		NoCrossNamespaceRefs:     o.options.NoCrossNamespaceRefs,
 	})
 	if err != nil {
 		return errors.Wrapf(err, "error registering component controller")
@@ -137,5 +230,22 @@ func (o *Operator) Setup(mgr ctrl.Manager) error {
 		return errors.Wrapf(err, "error registering http repository checker")
 	}
 
+	if err := ocirepository.SetupWithManager(mgr, componentReconciler); err != nil {
+		return errors.Wrapf(err, "error registering oci repository checker")
+	}
+
+	if err := gitrepository.SetupWithManager(mgr, componentReconciler); err != nil {
+		return errors.Wrapf(err, "error registering git repository checker")
+	}
+
+	if o.options.EnableWebhooks {
+		if err := webhooks.SetupWithManager(mgr); err != nil {
+			return errors.Wrapf(err, "error registering webhooks")
+		}
+		if err := mgr.AddReadyzCheck("webhooks", mgr.GetWebhookServer().StartedChecker()); err != nil {
+			return errors.Wrapf(err, "error registering webhook readiness check")
+		}
+	}
+
 	return nil
 }